@@ -0,0 +1,317 @@
+package algo
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// araCheckInterval is the number of expansions between cancellation checks.
+const araCheckInterval = 256
+
+// ARAStar implements Anytime Repairing A* (Likhachev, Gordon & Thrun, 2003).
+// ARA* runs a series of weighted A* searches with decreasing heuristic
+// weights. The first search uses a high weight and quickly returns a path
+// whose cost is within a known bound of optimal; each later search lowers
+// the weight and reuses the g-costs of the previous one, only re-expanding
+// nodes whose costs improved. The search stops when the weight reaches 1
+// (an optimal path) or when the context is cancelled or its deadline passes.
+//
+// ARAStar is built on AStar: it shares its open set, node bookkeeping and
+// weighted f-cost calculation, so heuristic and grid requirements are the same.
+type ARAStar struct {
+	// search holds the grid, heuristic, open/closed sets and current weight
+	search *AStar
+
+	// initialWeight is the heuristic weight of the first search
+	initialWeight float64
+
+	// weightStep is subtracted from the weight after each search
+	weightStep float64
+
+	// visited marks nodes with a finite g-cost in the current query
+	visited map[nodeKey]bool
+
+	// incons holds closed nodes whose g-cost improved (inconsistent nodes)
+	incons map[nodeKey]*Node
+}
+
+// NewARAStar creates a new ARA* planner instance.
+// By default, it starts with weight 3.0, decreases it by 0.5 per iteration
+// and uses the Manhattan heuristic.
+//
+// Returns:
+//
+//	*ARAStar: new ARA* planner instance
+func NewARAStar() *ARAStar {
+	return &ARAStar{
+		search:        NewAStar(),
+		initialWeight: 3.0,
+		weightStep:    0.5,
+		visited:       make(map[nodeKey]bool),
+		incons:        make(map[nodeKey]*Node),
+	}
+}
+
+// SetGrid configures the grid/search space for the planner.
+// This must be called before FindPath or Search.
+//
+// Params:
+//
+//	grid: grid to search within
+func (ara *ARAStar) SetGrid(grid SearchSpace) {
+	ara.search.SetGrid(grid)
+}
+
+// SetHeuristic configures the heuristic function for the planner.
+// The heuristic must be admissible for the reported bounds to hold.
+//
+// Params:
+//
+//	heuristic: heuristic function to use
+func (ara *ARAStar) SetHeuristic(heuristic HeuristicFunc) {
+	ara.search.SetHeuristic(heuristic)
+}
+
+// SetWeights configures the weight schedule. The first search uses
+// initial; each subsequent search lowers the weight by step until it
+// reaches 1.
+//
+// Params:
+//
+//	initial: weight of the first search (must be >= 1)
+//	step: amount subtracted after each search (must be > 0)
+//
+// Returns:
+//
+//	error: if initial < 1 or step <= 0
+func (ara *ARAStar) SetWeights(initial, step float64) error {
+	if math.IsNaN(initial) || math.IsInf(initial, 0) || initial < 1 {
+		return fmt.Errorf("initial weight must be a finite number >= 1, got %v", initial)
+	}
+	if math.IsNaN(step) || step <= 0 {
+		return fmt.Errorf("weight step must be > 0, got %v", step)
+	}
+	ara.initialWeight = initial
+	ara.weightStep = step
+	return nil
+}
+
+// FindPath runs ARA* to completion and returns the optimal path.
+// Use Search with a context to stop early with a bounded-suboptimal path.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	[]*Node: path from start to goal (including both endpoints)
+//	error: if no path exists or invalid input
+func (ara *ARAStar) FindPath(start, goal *Node) ([]*Node, error) {
+	result, err := ara.Search(context.Background(), start, goal, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result.Path, nil
+}
+
+// Search runs ARA* until an optimal path is found or ctx is done.
+// Every time a better path is found, onImprove (if non-nil) is called with
+// the path, its cost and the current suboptimality bound; the callback runs
+// synchronously on the searching goroutine and must not modify the path.
+// Use context.WithTimeout or context.WithDeadline to give the search a time
+// budget.
+//
+// Params:
+//
+//	ctx: context controlling cancellation and deadline
+//	start: starting node
+//	goal: destination node
+//	onImprove: optional callback receiving each improved result
+//
+// Returns:
+//
+//	*SearchResult: best result found; Bound is its suboptimality bound
+//	error: if no path exists, invalid input, or ctx ended before any path was found
+func (ara *ARAStar) Search(ctx context.Context, start, goal *Node, onImprove func(*SearchResult)) (*SearchResult, error) {
+	a := ara.search
+	if err := validateEndpoints(a.grid, start, goal); err != nil {
+		return nil, err
+	}
+
+	if a.heuristic == nil {
+		return nil, fmt.Errorf("heuristic must be set before calling FindPath")
+	}
+
+	if start.Equals(goal) {
+		result := &SearchResult{Path: []*Node{start}, Optimal: true, Bound: 1}
+		if onImprove != nil {
+			onImprove(result)
+		}
+		return result, nil
+	}
+
+	a.reset()
+	ara.visited = make(map[nodeKey]bool)
+	ara.incons = make(map[nodeKey]*Node)
+	a.weight = ara.initialWeight
+
+	start.G = 0
+	start.H = a.heuristic(start, goal)
+	a.calculateF(start)
+	start.Parent = nil
+	ara.visited[keyOf(start)] = true
+	a.openSet.PushNode(start)
+
+	var best *SearchResult
+	expanded := 0
+
+	for {
+		n, err := ara.improvePath(ctx, goal)
+		expanded += n
+		if err != nil {
+			// Deadline or cancellation: return the best path so far, if any
+			if best != nil {
+				return best, nil
+			}
+			return nil, err
+		}
+
+		if !ara.visited[keyOf(goal)] {
+			return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+				start.X, start.Y, goal.X, goal.Y)
+		}
+
+		// Ancestors may have improved after the goal was reached, so the
+		// reconstructed path can be cheaper than goal.G; report its real cost
+		path := reconstructPath(goal)
+		cost := PathCost(a.grid, path)
+		bound := math.Min(a.weight, cost/ara.minUnweightedF())
+		if bound < 1 {
+			bound = 1
+		}
+
+		if best == nil || cost < best.Cost || bound < best.Bound {
+			best = &SearchResult{
+				Path:          path,
+				Cost:          cost,
+				NodesExpanded: expanded,
+				Optimal:       bound == 1,
+				Bound:         bound,
+			}
+			if onImprove != nil {
+				onImprove(best)
+			}
+		}
+
+		if bound == 1 {
+			return best, nil
+		}
+
+		// Lower the weight, move inconsistent nodes back into the open set
+		// and reorder it for the new weight
+		a.weight = math.Max(1, a.weight-ara.weightStep)
+		ara.reopen()
+	}
+}
+
+// improvePath expands nodes until the goal's weighted f-cost is no larger
+// than any queued node's, i.e. until the current weight's solution is known.
+//
+// Params:
+//
+//	ctx: context checked every araCheckInterval expansions
+//	goal: destination node
+//
+// Returns:
+//
+//	int: number of expanded nodes
+//	error: ctx.Err() if the context ended during the search
+func (ara *ARAStar) improvePath(ctx context.Context, goal *Node) (int, error) {
+	a := ara.search
+	expanded := 0
+
+	for !a.openSet.IsEmpty() {
+		if ara.visited[keyOf(goal)] && goal.G <= a.openSet.Peek().F {
+			return expanded, nil
+		}
+
+		if expanded%araCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return expanded, err
+			}
+		}
+
+		current := a.openSet.PopNode()
+		a.closedSet[keyOf(current)] = true
+		expanded++
+
+		for _, neighbor := range a.grid.GetNeighbors(current) {
+			if neighbor.IsObstacle {
+				continue
+			}
+
+			coord := keyOf(neighbor)
+			newG := current.G + a.grid.GetCost(current, neighbor)
+			if ara.visited[coord] && newG >= neighbor.G {
+				continue
+			}
+
+			if !ara.visited[coord] {
+				neighbor.H = a.heuristic(neighbor, goal)
+			}
+			ara.visited[coord] = true
+			neighbor.G = newG
+			a.calculateF(neighbor)
+			neighbor.Parent = current
+
+			// Closed nodes are not re-expanded within one search; defer them
+			if a.closedSet[keyOf(neighbor)] {
+				ara.incons[coord] = neighbor
+			} else if a.openSet.ContainsNode(neighbor) {
+				a.openSet.UpdateNodePriority(neighbor, neighbor.F)
+			} else {
+				a.openSet.PushNode(neighbor)
+			}
+		}
+	}
+
+	return expanded, ctx.Err()
+}
+
+// minUnweightedF returns the smallest g + h over all open and inconsistent
+// nodes, a lower bound on the optimal path cost.
+func (ara *ARAStar) minUnweightedF() float64 {
+	lower := math.Inf(1)
+	for _, node := range ara.search.openSet.nodes {
+		lower = math.Min(lower, node.G+node.H)
+	}
+	for _, node := range ara.incons {
+		lower = math.Min(lower, node.G+node.H)
+	}
+	return lower
+}
+
+// reopen merges inconsistent nodes into the open set, recomputes every
+// open node's f-cost with the current weight and clears the closed set.
+func (ara *ARAStar) reopen() {
+	a := ara.search
+	open := make([]*Node, 0, len(a.openSet.nodes)+len(ara.incons))
+	open = append(open, a.openSet.nodes...)
+	for _, node := range ara.incons {
+		if !a.openSet.ContainsNode(node) {
+			open = append(open, node)
+		}
+	}
+
+	a.openSet.Clear()
+	for _, node := range open {
+		a.calculateF(node)
+		a.openSet.PushNode(node)
+	}
+
+	ara.incons = make(map[nodeKey]*Node)
+	a.closedSet = make(map[nodeKey]bool)
+}
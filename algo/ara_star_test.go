@@ -0,0 +1,192 @@
+package algo
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+// newARATestGrid builds a weighted maze where weighted A* is suboptimal
+func newARATestGrid() *Grid {
+	grid, _ := NewGrid(40, 40, EightWay)
+	for x := 0; x < 40; x++ {
+		for y := 0; y < 40; y++ {
+			node, _ := grid.GetNode(x, y)
+			node.Cost = float64(1 + (x*3+y*5)%4)
+			if x%8 == 4 && y != (x*7)%40 && y != (x*13)%40 {
+				node.IsObstacle = true
+			}
+		}
+	}
+	return grid
+}
+
+// TestARAStar_ImplementsPathfinder verifies interface compliance
+func TestARAStar_ImplementsPathfinder(t *testing.T) {
+	var _ Pathfinder = NewARAStar()
+}
+
+// TestARAStar_SetWeights tests weight schedule validation
+func TestARAStar_SetWeights(t *testing.T) {
+	tests := []struct {
+		name          string
+		initial, step float64
+		wantErr       bool
+	}{
+		{"valid", 5, 1, false},
+		{"already optimal", 1, 0.5, false},
+		{"initial below one", 0.5, 0.1, true},
+		{"infinite initial", math.Inf(1), 0.5, true},
+		{"zero step", 2, 0, true},
+		{"negative step", 2, -1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewARAStar().SetWeights(tt.initial, tt.step)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetWeights(%v, %v) error = %v, wantErr %v", tt.initial, tt.step, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestARAStar_Search_ImprovesToOptimal tests that each reported solution
+// respects its bound, solutions improve monotonically and the final one is optimal
+func TestARAStar_Search_ImprovesToOptimal(t *testing.T) {
+	grid := newARATestGrid()
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(39, 39)
+
+	dijkstra := NewDijkstra()
+	dijkstra.SetGrid(grid)
+	optimalPath, err := dijkstra.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("Dijkstra FindPath() returned error: %v", err)
+	}
+	optimal := PathCost(grid, optimalPath)
+
+	ara := NewARAStar()
+	ara.SetGrid(grid)
+	ara.SetHeuristic(Diagonal)
+	if err := ara.SetWeights(5, 1); err != nil {
+		t.Fatalf("SetWeights() returned error: %v", err)
+	}
+
+	var improvements []*SearchResult
+	final, err := ara.Search(context.Background(), start, goal, func(r *SearchResult) {
+		if math.Abs(PathCost(grid, r.Path)-r.Cost) > 1e-9 {
+			t.Errorf("Reported cost %.3f does not match path cost %.3f", r.Cost, PathCost(grid, r.Path))
+		}
+		assertContiguousPath(t, grid, r.Path)
+		improvements = append(improvements, r)
+	})
+	if err != nil {
+		t.Fatalf("Search() returned error: %v", err)
+	}
+
+	if len(improvements) == 0 {
+		t.Fatal("onImprove was never called")
+	}
+
+	for i, r := range improvements {
+		if r.Cost > r.Bound*optimal+1e-9 {
+			t.Errorf("improvement %d: cost %.3f exceeds bound %.3f x optimal %.3f", i, r.Cost, r.Bound, optimal)
+		}
+		if i > 0 && r.Bound > improvements[i-1].Bound {
+			t.Errorf("improvement %d: bound increased from %.3f to %.3f", i, improvements[i-1].Bound, r.Bound)
+		}
+		if i > 0 && r.Cost > improvements[i-1].Cost+1e-9 {
+			t.Errorf("improvement %d: cost increased from %.3f to %.3f", i, improvements[i-1].Cost, r.Cost)
+		}
+	}
+
+	if !final.Optimal || final.Bound != 1 {
+		t.Errorf("Final result should be optimal, got bound %.3f", final.Bound)
+	}
+
+	if math.Abs(final.Cost-optimal) > 1e-9 {
+		t.Errorf("Final cost %.3f differs from optimal %.3f", final.Cost, optimal)
+	}
+}
+
+// TestARAStar_Search_CancelledAfterFirstPath tests that cancellation returns
+// the best bounded-suboptimal path found so far
+func TestARAStar_Search_CancelledAfterFirstPath(t *testing.T) {
+	grid := newARATestGrid()
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(39, 39)
+
+	ara := NewARAStar()
+	ara.SetGrid(grid)
+	ara.SetHeuristic(Diagonal)
+	ara.SetWeights(5, 0.5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var first *SearchResult
+	result, err := ara.Search(ctx, start, goal, func(r *SearchResult) {
+		if first == nil {
+			first = r
+			cancel()
+		}
+	})
+	if err != nil {
+		t.Fatalf("Search() returned error: %v", err)
+	}
+
+	if result != first {
+		t.Error("Cancelled search should return the first (best so far) result")
+	}
+
+	if result.Bound > 5 {
+		t.Errorf("First result bound %.3f exceeds initial weight 5", result.Bound)
+	}
+}
+
+// TestARAStar_Search_CancelledBeforeAnyPath tests the error when no path was found in time
+func TestARAStar_Search_CancelledBeforeAnyPath(t *testing.T) {
+	grid := newARATestGrid()
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(39, 39)
+
+	ara := NewARAStar()
+	ara.SetGrid(grid)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := ara.Search(ctx, start, goal, nil); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+// TestARAStar_FindPath_InputValidation tests error handling for invalid inputs
+func TestARAStar_FindPath_InputValidation(t *testing.T) {
+	ara := NewARAStar()
+	grid, _ := NewGrid(5, 5, FourWay)
+	node, _ := grid.GetNode(0, 0)
+	other, _ := grid.GetNode(4, 2)
+
+	if _, err := ara.FindPath(nil, node); err == nil {
+		t.Error("FindPath() should return error for nil start node")
+	}
+
+	if _, err := ara.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when grid is not set")
+	}
+
+	ara.SetGrid(grid)
+	path, err := ara.FindPath(node, node)
+	if err != nil || len(path) != 1 {
+		t.Errorf("Expected single-node path for same start/goal, got %v (err=%v)", path, err)
+	}
+
+	for y := 0; y < 5; y++ {
+		grid.SetObstacle(2, y)
+	}
+	if path, err := ara.FindPath(node, other); err == nil || path != nil {
+		t.Error("FindPath() should return nil path and error when no path exists")
+	}
+}
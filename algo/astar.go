@@ -2,6 +2,7 @@ package algo
 
 import (
 	"fmt"
+	"math"
 )
 
 // AStar implements the A* pathfinding algorithm.
@@ -12,18 +13,25 @@ import (
 // and a closed set of already explored nodes. It selects nodes with the lowest
 // f-cost (g + h) for exploration, where g is the actual cost from start and
 // h is the heuristic estimate to the goal.
+//
+// A weight w >= 1 can be applied to the heuristic (weighted A*, f = g + w*h).
+// Larger weights expand fewer nodes at the price of optimality: with an
+// admissible heuristic the returned path costs at most w times the optimum.
 type AStar struct {
 	// grid is the search space we're pathfinding within
-	grid GridInterface
+	grid SearchSpace
 
 	// heuristic function estimates cost from current node to goal
 	heuristic HeuristicFunc
 
+	// weight multiplies the heuristic in the f-cost (1 = standard A*)
+	weight float64
+
 	// openSet contains nodes to be evaluated, ordered by f-cost
 	openSet *PriorityQueue
 
 	// closedSet contains nodes already evaluated (coordinates -> bool)
-	closedSet map[coordinate]bool
+	closedSet map[nodeKey]bool
 }
 
 // NewAStar creates a new A* pathfinder instance.
@@ -35,8 +43,9 @@ type AStar struct {
 func NewAStar() *AStar {
 	return &AStar{
 		heuristic: Manhattan, // Default to Manhattan heuristic
+		weight:    1.0,
 		openSet:   NewPriorityQueue(),
-		closedSet: make(map[coordinate]bool),
+		closedSet: make(map[nodeKey]bool),
 	}
 }
 
@@ -46,7 +55,7 @@ func NewAStar() *AStar {
 // Params:
 //
 //	grid: grid to search within
-func (a *AStar) SetGrid(grid GridInterface) {
+func (a *AStar) SetGrid(grid SearchSpace) {
 	a.grid = grid
 }
 
@@ -60,6 +69,46 @@ func (a *AStar) SetHeuristic(heuristic HeuristicFunc) {
 	a.heuristic = heuristic
 }
 
+// SetWeight configures the heuristic weight w used in f = g + w*h.
+// A weight of 1 gives standard, optimal A*. Weights above 1 make the search
+// greedier and faster, returning paths at most w times the optimal cost
+// when the heuristic is admissible.
+//
+// Params:
+//
+//	weight: heuristic weight (must be >= 1)
+//
+// Returns:
+//
+//	error: if weight is less than 1 or not a finite number
+func (a *AStar) SetWeight(weight float64) error {
+	if math.IsNaN(weight) || math.IsInf(weight, 0) || weight < 1 {
+		return fmt.Errorf("heuristic weight must be a finite number >= 1, got %v", weight)
+	}
+	a.weight = weight
+	return nil
+}
+
+// Weight returns the currently configured heuristic weight.
+//
+// Returns:
+//
+//	float64: heuristic weight (1 = standard A*)
+func (a *AStar) Weight() float64 {
+	return a.weight
+}
+
+// SuboptimalityBound returns the worst-case ratio between the cost of a path
+// returned by FindPath and the optimal cost, assuming an admissible heuristic.
+// For weighted A* this equals the weight.
+//
+// Returns:
+//
+//	float64: suboptimality bound (1 = optimal)
+func (a *AStar) SuboptimalityBound() float64 {
+	return a.weight
+}
+
 // FindPath finds the optimal path from start to goal using the A* algorithm.
 // Returns the complete path including start and goal nodes, or an error if no path exists.
 //
@@ -85,31 +134,145 @@ func (a *AStar) SetHeuristic(heuristic HeuristicFunc) {
 //	[]*Node: path from start to goal (including both endpoints)
 //	error: if no path exists or invalid input
 func (a *AStar) FindPath(start, goal *Node) ([]*Node, error) {
-	// Input validation
-	if start == nil || goal == nil {
-		return nil, fmt.Errorf("start and goal nodes cannot be nil")
+	result, err := a.Search(start, goal)
+	if err != nil {
+		return nil, err
 	}
+	return result.Path, nil
+}
 
-	if a.grid == nil {
-		return nil, fmt.Errorf("grid must be set before calling FindPath")
+// Search runs A* and returns a detailed result including the path cost,
+// the number of expanded nodes and the suboptimality bound implied by the
+// configured weight.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	*SearchResult: path, its cost and search statistics
+//	error: if no path exists or invalid input
+func (a *AStar) Search(start, goal *Node) (*SearchResult, error) {
+	// Input validation
+	if err := validateEndpoints(a.grid, start, goal); err != nil {
+		return nil, err
 	}
 
 	if a.heuristic == nil {
 		return nil, fmt.Errorf("heuristic must be set before calling FindPath")
 	}
 
-	// Check if start or goal are obstacles
-	if start.IsObstacle {
-		return nil, fmt.Errorf("start node at (%d, %d) is an obstacle", start.X, start.Y)
+	result, _, ok := a.search(start, []*Node{goal})
+	if !ok {
+		return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+			start.X, start.Y, goal.X, goal.Y)
 	}
+	return result, nil
+}
 
-	if goal.IsObstacle {
-		return nil, fmt.Errorf("goal node at (%d, %d) is an obstacle", goal.X, goal.Y)
+// FindPathToAny finds a path from start to the closest of several goals
+// with a single search, e.g. the nearest of many pickups. The heuristic of
+// a node is its minimum heuristic to any goal, which stays admissible
+// whenever the heuristic is admissible for each goal. As with FindPath, the
+// path is optimal at weight 1; otherwise its cost is at most
+// SuboptimalityBound times that of the cheapest path to any goal, and the
+// goal reached may not be the closest. Goals that are obstacles can never be reached and
+// are skipped.
+//
+// Params:
+//
+//	start: starting node
+//	goals: candidate destination nodes (at least one that is not an obstacle)
+//
+// Returns:
+//
+//	[]*Node: path from start to the reached goal (including both endpoints)
+//	*Node: the goal that was reached
+//	error: if no goal is reachable or invalid input
+func (a *AStar) FindPathToAny(start *Node, goals []*Node) ([]*Node, *Node, error) {
+	result, goal, err := a.SearchToAny(start, goals)
+	if err != nil {
+		return nil, nil, err
 	}
+	return result.Path, goal, nil
+}
 
-	// If start equals goal, return single-node path
-	if start.Equals(goal) {
-		return []*Node{start}, nil
+// SearchToAny runs a single A* toward the closest of several goals and
+// returns a detailed result along with the goal that was reached. The
+// result's Bound is the configured SuboptimalityBound. Goals that are
+// obstacles are skipped.
+//
+// Params:
+//
+//	start: starting node
+//	goals: candidate destination nodes (at least one that is not an obstacle)
+//
+// Returns:
+//
+//	*SearchResult: path, its cost and search statistics
+//	*Node: the goal that was reached
+//	error: if no goal is reachable or invalid input
+func (a *AStar) SearchToAny(start *Node, goals []*Node) (*SearchResult, *Node, error) {
+	if len(goals) == 0 {
+		return nil, nil, fmt.Errorf("at least one goal is required")
+	}
+
+	// An obstacle goal only rules itself out, not the whole query
+	usable := make([]*Node, 0, len(goals))
+	for _, goal := range goals {
+		if goal != nil && goal.IsObstacle {
+			continue
+		}
+		if err := validateEndpoints(a.grid, start, goal); err != nil {
+			return nil, nil, err
+		}
+		usable = append(usable, goal)
+	}
+
+	if len(usable) == 0 {
+		return nil, nil, fmt.Errorf("all %d goals are obstacles", len(goals))
+	}
+
+	if a.heuristic == nil {
+		return nil, nil, fmt.Errorf("heuristic must be set before calling FindPath")
+	}
+
+	result, goal, ok := a.search(start, usable)
+	if !ok {
+		return nil, nil, fmt.Errorf("no path found from (%d, %d) to any of %d goals",
+			start.X, start.Y, len(goals))
+	}
+	return result, goal, nil
+}
+
+// search is the A* loop shared by Search and SearchToAny. It stops at the
+// first goal removed from the open set, guided by the minimum heuristic
+// over all goals. Inputs must already be validated.
+//
+// Returns:
+//
+//	*SearchResult: path to the reached goal and search statistics
+//	*Node: the goal that was reached
+//	bool: false if no goal is reachable
+func (a *AStar) search(start *Node, goals []*Node) (*SearchResult, *Node, bool) {
+	targets := make(map[nodeKey]*Node, len(goals))
+	for _, goal := range goals {
+		targets[keyOf(goal)] = goal
+	}
+
+	// If start is a goal, return single-node path
+	if goal, ok := targets[keyOf(start)]; ok {
+		return a.newResult([]*Node{start}, 0, 0), goal, true
+	}
+
+	estimate := func(node *Node) float64 {
+		h := math.Inf(1)
+		for _, goal := range goals {
+			h = math.Min(h, a.heuristic(node, goal))
+		}
+		return h
 	}
 
 	// Initialize algorithm state
@@ -117,25 +280,27 @@ func (a *AStar) FindPath(start, goal *Node) ([]*Node, error) {
 
 	// Setup start node
 	start.G = 0
-	start.H = a.heuristic(start, goal)
-	start.CalculateF()
+	start.H = estimate(start)
+	a.calculateF(start)
 	start.Parent = nil
 
 	// Add start node to open set
 	a.openSet.PushNode(start)
 
 	// Main A* loop
+	expanded := 0
 	for !a.openSet.IsEmpty() {
 		// Get node with lowest f-cost
 		current := a.openSet.PopNode()
 
-		// Check if we reached the goal
-		if current.Equals(goal) {
-			return a.reconstructPath(current), nil
+		// Check if we reached a goal
+		if goal, ok := targets[keyOf(current)]; ok {
+			return a.newResult(reconstructPath(current), current.G, expanded), goal, true
 		}
 
 		// Move current to closed set
-		a.closedSet[coordinate{current.X, current.Y}] = true
+		a.closedSet[keyOf(current)] = true
+		expanded++
 
 		// Examine each neighbor
 		neighbors := a.grid.GetNeighbors(current)
@@ -154,28 +319,46 @@ func (a *AStar) FindPath(start, goal *Node) ([]*Node, error) {
 			if !inOpenSet || newG < neighbor.G {
 				// This path is better, record it
 				neighbor.G = newG
-				neighbor.H = a.heuristic(neighbor, goal)
-				neighbor.CalculateF()
+				neighbor.H = estimate(neighbor)
+				a.calculateF(neighbor)
 				neighbor.Parent = current
 
-				// Add to open set if not already there
+				// Add to open set, or restore heap order if already queued
 				if !inOpenSet {
 					a.openSet.PushNode(neighbor)
+				} else {
+					a.openSet.UpdateNodePriority(neighbor, neighbor.F)
 				}
 			}
 		}
 	}
 
-	// Open set is empty but goal not reached - no path exists
-	return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
-		start.X, start.Y, goal.X, goal.Y)
+	// Open set is empty but no goal reached - no path exists
+	return nil, nil, false
+}
+
+// calculateF updates a node's F cost using the weighted formula f = g + w*h.
+// The raw heuristic value is kept in H.
+func (a *AStar) calculateF(node *Node) {
+	node.F = node.G + a.weight*node.H
+}
+
+// newResult wraps a path in a SearchResult annotated with the current weight.
+func (a *AStar) newResult(path []*Node, cost float64, expanded int) *SearchResult {
+	return &SearchResult{
+		Path:          path,
+		Cost:          cost,
+		NodesExpanded: expanded,
+		Optimal:       a.weight == 1,
+		Bound:         a.weight,
+	}
 }
 
 // reset clears the algorithm state for a fresh pathfinding operation.
 // This clears the open and closed sets and resets the grid nodes.
 func (a *AStar) reset() {
 	a.openSet.Clear()
-	a.closedSet = make(map[coordinate]bool)
+	a.closedSet = make(map[nodeKey]bool)
 	if a.grid != nil {
 		a.grid.Reset()
 	}
@@ -191,33 +374,5 @@ func (a *AStar) reset() {
 //
 //	bool: true if node is in closed set
 func (a *AStar) isInClosedSet(node *Node) bool {
-	return a.closedSet[coordinate{node.X, node.Y}]
-}
-
-// reconstructPath builds the final path by following parent pointers from goal to start.
-// Returns the path in forward order (start to goal).
-//
-// Params:
-//
-//	goalNode: the goal node with parent chain leading to start
-//
-// Returns:
-//
-//	[]*Node: path from start to goal
-func (a *AStar) reconstructPath(goalNode *Node) []*Node {
-	var path []*Node
-	current := goalNode
-
-	// Follow parent chain from goal to start
-	for current != nil {
-		path = append(path, current)
-		current = current.Parent
-	}
-
-	// Reverse the path to get start -> goal order
-	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
-		path[i], path[j] = path[j], path[i]
-	}
-
-	return path
+	return a.closedSet[keyOf(node)]
 }
@@ -1,6 +1,7 @@
 package algo
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -216,3 +217,191 @@ func BenchmarkAStar_MemoryAllocation(b *testing.B) {
 		}
 	}
 }
+
+// BenchmarkBFS_MediumGrid compares BFS against A* on an unweighted 500x500 grid
+func BenchmarkBFS_MediumGrid(b *testing.B) {
+	grid, err := NewGrid(500, 500, FourWay)
+	if err != nil {
+		b.Fatalf("Failed to create grid: %v", err)
+	}
+
+	bfs := NewBFS()
+	bfs.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(499, 499)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := bfs.FindPath(start, goal)
+		if err != nil {
+			b.Fatalf("FindPath failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkAStar_Weighted compares heuristic weights on a large (1000x1000)
+// grid with obstacles. Higher weights trade optimality for fewer expansions.
+func BenchmarkAStar_Weighted(b *testing.B) {
+	grid, err := NewGrid(1000, 1000, FourWay)
+	if err != nil {
+		b.Fatalf("Failed to create grid: %v", err)
+	}
+
+	// Scatter obstacles so the heuristic is not perfectly informed
+	for x := 1; x < 999; x++ {
+		for y := 1; y < 999; y++ {
+			if (x*y)%7 == 3 {
+				node, _ := grid.GetNode(x, y)
+				node.IsObstacle = true
+			}
+		}
+	}
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(999, 999)
+
+	for _, w := range []float64{1, 1.5, 2, 5} {
+		b.Run(fmt.Sprintf("w=%.1f", w), func(b *testing.B) {
+			astar := NewAStar()
+			astar.SetGrid(grid)
+			if err := astar.SetWeight(w); err != nil {
+				b.Fatalf("SetWeight failed: %v", err)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, err := astar.FindPath(start, goal)
+				if err != nil {
+					b.Fatalf("FindPath failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkBidirectionalAStar_LargeGrid tests bidirectional A* on a large
+// (1000x1000) grid with scattered obstacles
+func BenchmarkBidirectionalAStar_LargeGrid(b *testing.B) {
+	grid, err := NewGrid(1000, 1000, FourWay)
+	if err != nil {
+		b.Fatalf("Failed to create grid: %v", err)
+	}
+
+	for x := 1; x < 999; x++ {
+		for y := 1; y < 999; y++ {
+			if (x*y)%7 == 3 {
+				node, _ := grid.GetNode(x, y)
+				node.IsObstacle = true
+			}
+		}
+	}
+
+	bidirectional := NewBidirectionalAStar()
+	bidirectional.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(999, 999)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := bidirectional.FindPath(start, goal)
+		if err != nil {
+			b.Fatalf("FindPath failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkJPS_LargeGrid tests Jump Point Search on a large (1000x1000)
+// 8-way grid with scattered obstacles
+func BenchmarkJPS_LargeGrid(b *testing.B) {
+	grid, err := NewGrid(1000, 1000, EightWay)
+	if err != nil {
+		b.Fatalf("Failed to create grid: %v", err)
+	}
+
+	for x := 1; x < 999; x++ {
+		for y := 1; y < 999; y++ {
+			if (x*y)%7 == 3 {
+				node, _ := grid.GetNode(x, y)
+				node.IsObstacle = true
+			}
+		}
+	}
+
+	jps := NewJPS()
+	jps.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(999, 999)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := jps.FindPath(start, goal)
+		if err != nil {
+			b.Fatalf("FindPath failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkHPAStar_MediumGrid tests HPA* queries on a medium (500x500)
+// grid with sparse obstacles; preprocessing is excluded from the timing
+func BenchmarkHPAStar_MediumGrid(b *testing.B) {
+	grid, err := NewGrid(500, 500, FourWay)
+	if err != nil {
+		b.Fatalf("Failed to create grid: %v", err)
+	}
+
+	for x := 1; x < 499; x++ {
+		for y := 1; y < 499; y++ {
+			if (x*y)%31 == 3 {
+				node, _ := grid.GetNode(x, y)
+				node.IsObstacle = true
+			}
+		}
+	}
+
+	hpa := NewHPAStar()
+	hpa.SetGrid(grid)
+	if err := hpa.Build(); err != nil {
+		b.Fatalf("Build failed: %v", err)
+	}
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(499, 499)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := hpa.FindPath(start, goal)
+		if err != nil {
+			b.Fatalf("FindPath failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkFlowField_MediumGrid tests computing a flow field over a medium
+// (500x500) grid with scattered obstacles
+func BenchmarkFlowField_MediumGrid(b *testing.B) {
+	grid, err := NewGrid(500, 500, EightWay)
+	if err != nil {
+		b.Fatalf("Failed to create grid: %v", err)
+	}
+
+	for x := 1; x < 499; x++ {
+		for y := 1; y < 499; y++ {
+			if (x*y)%7 == 3 {
+				node, _ := grid.GetNode(x, y)
+				node.IsObstacle = true
+			}
+		}
+	}
+
+	goal, _ := grid.GetNode(250, 250)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := NewFlowField(grid, goal); err != nil {
+			b.Fatalf("NewFlowField failed: %v", err)
+		}
+	}
+}
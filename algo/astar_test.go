@@ -1,6 +1,7 @@
 package algo
 
 import (
+	"math"
 	"testing"
 )
 
@@ -261,3 +262,197 @@ func TestAStar_FindPath_NoGrid(t *testing.T) {
 		t.Error("FindPath() should return error when grid is not set")
 	}
 }
+
+// TestAStar_SetWeight tests heuristic weight validation
+func TestAStar_SetWeight(t *testing.T) {
+	tests := []struct {
+		name    string
+		weight  float64
+		wantErr bool
+	}{
+		{"standard A*", 1.0, false},
+		{"weighted", 1.5, false},
+		{"large weight", 5.0, false},
+		{"below one", 0.5, true},
+		{"zero", 0, true},
+		{"negative", -2, true},
+		{"NaN", math.NaN(), true},
+		{"infinite", math.Inf(1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			astar := NewAStar()
+			err := astar.SetWeight(tt.weight)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetWeight(%v) error = %v, wantErr %v", tt.weight, err, tt.wantErr)
+			}
+
+			if tt.wantErr && astar.Weight() != 1.0 {
+				t.Errorf("Invalid weight should leave default 1.0, got %v", astar.Weight())
+			}
+
+			if !tt.wantErr && astar.SuboptimalityBound() != tt.weight {
+				t.Errorf("SuboptimalityBound() = %v, expected %v", astar.SuboptimalityBound(), tt.weight)
+			}
+		})
+	}
+}
+
+// TestAStar_Search_WeightedBound tests that weighted A* respects its bound
+// and expands no more nodes than standard A*
+func TestAStar_Search_WeightedBound(t *testing.T) {
+	grid, _ := NewGrid(40, 40, FourWay)
+	for x := 0; x < 40; x++ {
+		for y := 0; y < 40; y++ {
+			if (x*y)%7 == 3 {
+				grid.SetObstacle(x, y)
+			}
+		}
+	}
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(39, 39)
+
+	astar := NewAStar()
+	astar.SetGrid(grid)
+	optimal, err := astar.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search() returned error: %v", err)
+	}
+
+	if !optimal.Optimal || optimal.Bound != 1.0 {
+		t.Errorf("Unweighted result should be optimal with bound 1, got %v/%v", optimal.Optimal, optimal.Bound)
+	}
+
+	for _, w := range []float64{1.5, 2, 5} {
+		if err := astar.SetWeight(w); err != nil {
+			t.Fatalf("SetWeight(%v) returned error: %v", w, err)
+		}
+
+		result, err := astar.Search(start, goal)
+		if err != nil {
+			t.Fatalf("Search() with weight %v returned error: %v", w, err)
+		}
+
+		if result.Optimal {
+			t.Errorf("Weight %v result should not be reported optimal", w)
+		}
+
+		if result.Cost > w*optimal.Cost+1e-9 {
+			t.Errorf("Weight %v cost %.2f exceeds bound %.2f", w, result.Cost, w*optimal.Cost)
+		}
+
+		if result.Cost != PathCost(grid, result.Path) {
+			t.Errorf("Result cost %.2f does not match path cost %.2f", result.Cost, PathCost(grid, result.Path))
+		}
+	}
+}
+
+// TestAStar_FindPathToAny tests that the multi-goal search reaches the
+// goal with the cheapest path, matching one search per goal
+func TestAStar_FindPathToAny(t *testing.T) {
+	grid, _ := NewGrid(20, 20, EightWay)
+	for x := 0; x < 20; x++ {
+		for y := 0; y < 20; y++ {
+			if (x*y)%7 == 3 {
+				grid.SetObstacle(x, y)
+			}
+		}
+	}
+	// Wall off the geometrically closest goal so it is not the cheapest
+	for y := 0; y < 8; y++ {
+		grid.SetObstacle(6, y)
+	}
+
+	start, _ := grid.GetNode(4, 2)
+	var goals []*Node
+	for _, c := range [][2]int{{8, 2}, {2, 12}, {19, 19}, {15, 0}} {
+		goal, _ := grid.GetNode(c[0], c[1])
+		goal.IsObstacle = false
+		goals = append(goals, goal)
+	}
+
+	astar := NewAStar()
+	astar.SetGrid(grid)
+	astar.SetHeuristic(Diagonal)
+
+	best := math.Inf(1)
+	var bestGoal *Node
+	for _, goal := range goals {
+		result, err := astar.Search(start, goal)
+		if err == nil && result.Cost < best {
+			best, bestGoal = result.Cost, goal
+		}
+	}
+
+	result, reached, err := astar.SearchToAny(start, goals)
+	if err != nil {
+		t.Fatalf("SearchToAny() returned error: %v", err)
+	}
+
+	if math.Abs(result.Cost-best) > 1e-9 {
+		t.Errorf("Expected cost %.3f, got %.3f", best, result.Cost)
+	}
+	if !reached.Equals(bestGoal) {
+		t.Errorf("Expected goal (%d, %d), reached (%d, %d)", bestGoal.X, bestGoal.Y, reached.X, reached.Y)
+	}
+
+	path, reached, err := astar.FindPathToAny(start, goals)
+	if err != nil {
+		t.Fatalf("FindPathToAny() returned error: %v", err)
+	}
+	assertContiguousPath(t, grid, path)
+	if !path[len(path)-1].Equals(reached) {
+		t.Error("Path should end at the reported goal")
+	}
+}
+
+// TestAStar_FindPathToAny_InputValidation tests error handling for the
+// multi-goal search
+func TestAStar_FindPathToAny_InputValidation(t *testing.T) {
+	astar := NewAStar()
+	grid, _ := NewGrid(5, 5, FourWay)
+	start, _ := grid.GetNode(0, 0)
+	a, _ := grid.GetNode(4, 0)
+	b, _ := grid.GetNode(4, 4)
+
+	if _, _, err := astar.FindPathToAny(start, []*Node{a}); err == nil {
+		t.Error("FindPathToAny() should return error when grid is not set")
+	}
+
+	astar.SetGrid(grid)
+	if _, _, err := astar.FindPathToAny(start, nil); err == nil {
+		t.Error("FindPathToAny() should return error for no goals")
+	}
+	if _, _, err := astar.FindPathToAny(start, []*Node{a, nil}); err == nil {
+		t.Error("FindPathToAny() should return error for a nil goal")
+	}
+
+	path, reached, err := astar.FindPathToAny(start, []*Node{a, start})
+	if err != nil || len(path) != 1 || !reached.Equals(start) {
+		t.Errorf("Expected single-node path when start is a goal, got %v (err=%v)", path, err)
+	}
+
+	// Obstacle goals are skipped; only a set of obstacles is an error
+	grid.SetObstacle(4, 0)
+	path, reached, err = astar.FindPathToAny(start, []*Node{a, b})
+	if err != nil {
+		t.Fatalf("FindPathToAny() should skip the obstacle goal, got error: %v", err)
+	}
+	if !reached.Equals(b) || !path[len(path)-1].Equals(b) {
+		t.Errorf("Expected to reach (4, 4), reached (%d, %d)", reached.X, reached.Y)
+	}
+	if _, _, err := astar.FindPathToAny(start, []*Node{a}); err == nil {
+		t.Error("FindPathToAny() should return error when every goal is an obstacle")
+	}
+	grid.ClearObstacle(4, 0)
+
+	for y := 0; y < 5; y++ {
+		grid.SetObstacle(2, y)
+	}
+	if _, _, err := astar.FindPathToAny(start, []*Node{a, b}); err == nil {
+		t.Error("FindPathToAny() should return error when no goal is reachable")
+	}
+}
@@ -0,0 +1,135 @@
+package algo
+
+import (
+	"fmt"
+)
+
+// BFS implements breadth-first search for unweighted grids.
+// Nodes are expanded in FIFO order using a plain slice-backed queue, so the
+// first time the goal is reached the path has the fewest possible moves.
+// This avoids the heap overhead of AStar, but is only optimal when every
+// move costs exactly 1.0 (e.g. a FourWay Grid with default Node.Cost).
+//
+// BFS refuses to return a possibly wrong answer: FindPath returns an error
+// for a *Grid without FourWay movement or with non-unit node costs, and for
+// any other search space as soon as a move with non-unit cost is seen.
+type BFS struct {
+	// grid is the search space we're pathfinding within
+	grid SearchSpace
+
+	// queue holds discovered nodes waiting to be expanded (FIFO)
+	queue []*Node
+
+	// visited contains nodes already discovered (coordinates -> bool)
+	visited map[nodeKey]bool
+}
+
+// NewBFS creates a new breadth-first search pathfinder instance.
+//
+// Returns:
+//
+//	*BFS: new BFS pathfinder instance
+func NewBFS() *BFS {
+	return &BFS{
+		queue:   make([]*Node, 0),
+		visited: make(map[nodeKey]bool),
+	}
+}
+
+// SetGrid configures the grid/search space for the pathfinder.
+// This must be called before FindPath.
+//
+// Params:
+//
+//	grid: grid to search within
+func (b *BFS) SetGrid(grid SearchSpace) {
+	b.grid = grid
+}
+
+// SetHeuristic is a no-op for BFS.
+// Breadth-first search explores by depth only; the method exists so
+// BFS satisfies the Pathfinder interface.
+//
+// Params:
+//
+//	heuristic: ignored
+func (b *BFS) SetHeuristic(heuristic HeuristicFunc) {}
+
+// FindPath finds the path with the fewest moves from start to goal.
+// Returns the complete path including start and goal nodes, or an error if
+// no path exists or the grid does not have unit movement costs.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	[]*Node: path from start to goal (including both endpoints)
+//	error: if no path exists, movement is not FourWay, costs are non-unit, or invalid input
+func (b *BFS) FindPath(start, goal *Node) ([]*Node, error) {
+	if err := validateEndpoints(b.grid, start, goal); err != nil {
+		return nil, err
+	}
+
+	// Reject diagonal movement and weighted grids up front when we can
+	// inspect the whole grid, so the outcome never depends on which moves
+	// the search happens to try first
+	if g, ok := b.grid.(*Grid); ok {
+		if g.MovementType != FourWay {
+			return nil, fmt.Errorf("BFS requires FourWay movement, got %s", g.movementTypeString())
+		}
+		if !g.HasUniformCost() {
+			return nil, fmt.Errorf("BFS requires unit movement costs; grid has weighted nodes")
+		}
+	}
+
+	if start.Equals(goal) {
+		return []*Node{start}, nil
+	}
+
+	b.reset()
+
+	start.Parent = nil
+	b.queue = append(b.queue, start)
+	b.visited[keyOf(start)] = true
+
+	for head := 0; head < len(b.queue); head++ {
+		current := b.queue[head]
+
+		for _, neighbor := range b.grid.GetNeighbors(current) {
+			if neighbor.IsObstacle || b.visited[keyOf(neighbor)] {
+				continue
+			}
+
+			// Diagonal moves or custom SearchSpace costs break BFS optimality
+			if cost := b.grid.GetCost(current, neighbor); cost != 1.0 {
+				return nil, fmt.Errorf("BFS requires unit movement costs; move (%d, %d) -> (%d, %d) costs %.3f",
+					current.X, current.Y, neighbor.X, neighbor.Y, cost)
+			}
+
+			neighbor.G = current.G + 1
+			neighbor.Parent = current
+			b.visited[keyOf(neighbor)] = true
+
+			if neighbor.Equals(goal) {
+				return reconstructPath(neighbor), nil
+			}
+
+			b.queue = append(b.queue, neighbor)
+		}
+	}
+
+	return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+		start.X, start.Y, goal.X, goal.Y)
+}
+
+// reset clears the algorithm state for a fresh pathfinding operation.
+func (b *BFS) reset() {
+	b.queue = b.queue[:0]
+	b.visited = make(map[nodeKey]bool)
+	if b.grid != nil {
+		b.grid.Reset()
+	}
+}
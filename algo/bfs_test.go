@@ -0,0 +1,153 @@
+package algo
+
+import (
+	"testing"
+)
+
+// TestBFS_ImplementsPathfinder verifies interface compliance
+func TestBFS_ImplementsPathfinder(t *testing.T) {
+	var _ Pathfinder = NewBFS()
+}
+
+// TestBFS_FindPath_SimpleCase tests basic pathfinding
+func TestBFS_FindPath_SimpleCase(t *testing.T) {
+	grid, _ := NewGrid(5, 5, FourWay)
+	b := NewBFS()
+	b.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(4, 4)
+
+	path, err := b.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+
+	if !path[0].Equals(start) || !path[len(path)-1].Equals(goal) {
+		t.Errorf("Path should run from start to goal, got %v -> %v", path[0], path[len(path)-1])
+	}
+
+	if len(path) != 9 {
+		t.Errorf("Expected path length 9, got %d", len(path))
+	}
+}
+
+// TestBFS_MatchesAStar verifies BFS finds paths as short as A* on mazes
+func TestBFS_MatchesAStar(t *testing.T) {
+	grid, _ := NewGrid(15, 15, FourWay)
+	for x := 2; x < 14; x += 4 {
+		gap := (x * 7) % 15
+		for y := 0; y < 15; y++ {
+			if y != gap {
+				grid.SetObstacle(x, y)
+			}
+		}
+	}
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(14, 14)
+
+	b := NewBFS()
+	b.SetGrid(grid)
+	bPath, err := b.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("BFS FindPath() returned error: %v", err)
+	}
+	bLen := len(bPath)
+
+	a := NewAStar()
+	a.SetGrid(grid)
+	aPath, err := a.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("AStar FindPath() returned error: %v", err)
+	}
+
+	if bLen != len(aPath) {
+		t.Errorf("BFS path length %d differs from A* path length %d", bLen, len(aPath))
+	}
+
+	for _, node := range bPath {
+		if node.IsObstacle {
+			t.Errorf("Path goes through obstacle at (%d, %d)", node.X, node.Y)
+		}
+	}
+}
+
+// TestBFS_RejectsNonUnitCosts tests that weighted grids return an error
+func TestBFS_RejectsNonUnitCosts(t *testing.T) {
+	t.Run("weighted node", func(t *testing.T) {
+		grid, _ := NewGrid(5, 5, FourWay)
+		node, _ := grid.GetNode(4, 0)
+		node.Cost = 3.0
+
+		b := NewBFS()
+		b.SetGrid(grid)
+		start, _ := grid.GetNode(0, 0)
+		goal, _ := grid.GetNode(1, 0)
+
+		if _, err := b.FindPath(start, goal); err == nil {
+			t.Error("FindPath() should reject grids with non-unit Node.Cost")
+		}
+	})
+
+	t.Run("eight-way movement", func(t *testing.T) {
+		grid, _ := NewGrid(5, 5, EightWay)
+		b := NewBFS()
+		b.SetGrid(grid)
+		start, _ := grid.GetNode(0, 0)
+
+		// Rejected for every goal, including ones reached before any
+		// diagonal move is tried
+		for _, c := range [][2]int{{1, 0}, {0, 1}, {4, 4}} {
+			goal, _ := grid.GetNode(c[0], c[1])
+			if _, err := b.FindPath(start, goal); err == nil {
+				t.Errorf("FindPath() to (%d, %d) should reject EightWay grids", c[0], c[1])
+			}
+		}
+	})
+}
+
+// TestBFS_FindPath_NoPath tests when no path exists
+func TestBFS_FindPath_NoPath(t *testing.T) {
+	grid, _ := NewGrid(5, 5, FourWay)
+	for y := 0; y < 5; y++ {
+		grid.SetObstacle(2, y)
+	}
+
+	b := NewBFS()
+	b.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 2)
+	goal, _ := grid.GetNode(4, 2)
+
+	path, err := b.FindPath(start, goal)
+	if err == nil {
+		t.Fatal("FindPath() should return error when no path exists")
+	}
+
+	if path != nil {
+		t.Error("FindPath() should return nil path when no path exists")
+	}
+}
+
+// TestBFS_FindPath_InputValidation tests error handling for invalid inputs
+func TestBFS_FindPath_InputValidation(t *testing.T) {
+	b := NewBFS()
+	grid, _ := NewGrid(5, 5, FourWay)
+	node, _ := grid.GetNode(0, 0)
+
+	if _, err := b.FindPath(node, nil); err == nil {
+		t.Error("FindPath() should return error for nil goal node")
+	}
+
+	if _, err := b.FindPath(node, node); err == nil {
+		t.Error("FindPath() should return error when grid is not set")
+	}
+
+	b.SetGrid(grid)
+
+	path, err := b.FindPath(node, node)
+	if err != nil || len(path) != 1 {
+		t.Errorf("Expected single-node path for same start/goal, got %v (err=%v)", path, err)
+	}
+}
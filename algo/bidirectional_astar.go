@@ -0,0 +1,274 @@
+package algo
+
+import (
+	"fmt"
+)
+
+// BidirectionalAStar implements bidirectional A* search.
+// Two A* searches run simultaneously: a forward search from the start and a
+// backward search from the goal. On long queries each frontier only has to
+// cover roughly half the distance, which greatly reduces the number of
+// expanded nodes compared to unidirectional AStar.
+//
+// Directed costs are supported: the backward search relaxes the edge
+// u -> v with GetCost(u, v), so GetCost(a, b) != GetCost(b, a) is handled
+// correctly. The backward search follows incoming edges when the search
+// space provides them, as Graph does; otherwise neighbor relations are
+// assumed to be symmetric (if b is a neighbor of a then a is a neighbor
+// of b), which holds for Grid.
+//
+// Each search keeps its own g-costs and parents, so Node.G/H/F/Parent are
+// not used during the search.
+type BidirectionalAStar struct {
+	// grid is the search space we're pathfinding within
+	grid SearchSpace
+
+	// heuristic function estimates cost between two nodes
+	heuristic HeuristicFunc
+
+	// forward and backward hold the state of each search direction
+	forward, backward *searchFrontier
+}
+
+// predecessorSpace is implemented by search spaces with directed moves that
+// can list the nodes with a move into a given node (e.g. Graph).
+type predecessorSpace interface {
+	SearchSpace
+	GetPredecessors(node *Node) []*Node
+}
+
+// searchFrontier holds the state of one direction of a bidirectional search.
+type searchFrontier struct {
+	// target is the node this direction is searching toward
+	target *Node
+
+	// openSet contains discovered nodes ordered by f-cost (lazy deletion)
+	openSet entryQueue
+
+	// g maps coordinates to the best known cost from this direction's origin
+	g map[nodeKey]float64
+
+	// parent maps coordinates to the previous node on the best known path
+	parent map[nodeKey]*Node
+
+	// closedSet contains nodes already expanded in this direction
+	closedSet map[nodeKey]bool
+}
+
+// newSearchFrontier creates empty state for one search direction.
+func newSearchFrontier() *searchFrontier {
+	return &searchFrontier{
+		g:         make(map[nodeKey]float64),
+		parent:    make(map[nodeKey]*Node),
+		closedSet: make(map[nodeKey]bool),
+	}
+}
+
+// reset clears the frontier and seeds it with an origin node.
+func (f *searchFrontier) reset(origin, target *Node, h float64) {
+	f.target = target
+	f.openSet.clear()
+	f.g = make(map[nodeKey]float64)
+	f.parent = make(map[nodeKey]*Node)
+	f.closedSet = make(map[nodeKey]bool)
+
+	f.g[keyOf(origin)] = 0
+	f.openSet.push(origin, h, 0)
+}
+
+// NewBidirectionalAStar creates a new bidirectional A* pathfinder instance.
+// By default, it uses the Manhattan heuristic.
+//
+// Returns:
+//
+//	*BidirectionalAStar: new bidirectional A* pathfinder instance
+func NewBidirectionalAStar() *BidirectionalAStar {
+	return &BidirectionalAStar{
+		heuristic: Manhattan,
+		forward:   newSearchFrontier(),
+		backward:  newSearchFrontier(),
+	}
+}
+
+// SetGrid configures the grid/search space for the pathfinder.
+// This must be called before FindPath.
+//
+// Params:
+//
+//	grid: grid to search within
+func (b *BidirectionalAStar) SetGrid(grid SearchSpace) {
+	b.grid = grid
+}
+
+// SetHeuristic configures the heuristic function for both search directions.
+// The heuristic must be admissible and consistent (and symmetric, since the
+// backward search estimates the distance back to the start) for the
+// returned path to be optimal.
+//
+// Params:
+//
+//	heuristic: heuristic function to use
+func (b *BidirectionalAStar) SetHeuristic(heuristic HeuristicFunc) {
+	b.heuristic = heuristic
+}
+
+// FindPath finds the optimal path from start to goal using bidirectional A*.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	[]*Node: path from start to goal (including both endpoints)
+//	error: if no path exists or invalid input
+func (b *BidirectionalAStar) FindPath(start, goal *Node) ([]*Node, error) {
+	result, err := b.Search(start, goal)
+	if err != nil {
+		return nil, err
+	}
+	return result.Path, nil
+}
+
+// Search runs bidirectional A* and returns a detailed result.
+//
+// Algorithm:
+//  1. Seed the forward frontier with start and the backward frontier with goal
+//  2. Repeatedly expand the smaller frontier; whenever a node has been reached
+//     from both sides, update the best meeting cost mu
+//  3. Stop once the smallest f-cost in either frontier is >= mu: no path
+//     through an unexpanded node can then be cheaper than mu
+//  4. Join the forward parents and backward parents at the meeting node
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	*SearchResult: path, its cost and search statistics
+//	error: if no path exists or invalid input
+func (b *BidirectionalAStar) Search(start, goal *Node) (*SearchResult, error) {
+	if err := validateEndpoints(b.grid, start, goal); err != nil {
+		return nil, err
+	}
+
+	if b.heuristic == nil {
+		return nil, fmt.Errorf("heuristic must be set before calling FindPath")
+	}
+
+	if start.Equals(goal) {
+		return &SearchResult{Path: []*Node{start}, Optimal: true, Bound: 1}, nil
+	}
+
+	b.grid.Reset()
+	b.forward.reset(start, goal, b.heuristic(start, goal))
+	b.backward.reset(goal, start, b.heuristic(goal, start))
+
+	var meet *Node
+	mu := 0.0
+	found := false
+	expanded := 0
+
+	for b.forward.openSet.Len() > 0 && b.backward.openSet.Len() > 0 {
+		// Termination: every undiscovered path costs at least the smallest f in each frontier
+		if found && (b.forward.openSet.peekPriority() >= mu || b.backward.openSet.peekPriority() >= mu) {
+			break
+		}
+
+		// Expand the direction with fewer queued entries to keep frontiers balanced
+		current, other, isForward := b.forward, b.backward, true
+		if b.backward.openSet.Len() < b.forward.openSet.Len() {
+			current, other, isForward = b.backward, b.forward, false
+		}
+
+		node := current.openSet.pop().node
+		coord := keyOf(node)
+		if current.closedSet[coord] {
+			continue // stale entry
+		}
+		current.closedSet[coord] = true
+		expanded++
+
+		for _, neighbor := range b.expand(node, isForward) {
+			if neighbor.IsObstacle {
+				continue
+			}
+
+			// Backward search walks edges in reverse: neighbor -> node
+			var cost float64
+			if isForward {
+				cost = b.grid.GetCost(node, neighbor)
+			} else {
+				cost = b.grid.GetCost(neighbor, node)
+			}
+
+			nCoord := keyOf(neighbor)
+			newG := current.g[coord] + cost
+			if oldG, seen := current.g[nCoord]; seen && newG >= oldG {
+				continue
+			}
+
+			current.g[nCoord] = newG
+			current.parent[nCoord] = node
+			delete(current.closedSet, nCoord)
+			// Prefer deeper nodes among equal f-costs so the frontiers meet sooner
+			current.openSet.push(neighbor, newG+b.heuristic(neighbor, current.target), -newG)
+
+			// A node reached from both sides yields a complete path
+			if otherG, ok := other.g[nCoord]; ok && (!found || newG+otherG < mu) {
+				mu = newG + otherG
+				meet = neighbor
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+			start.X, start.Y, goal.X, goal.Y)
+	}
+
+	return &SearchResult{
+		Path:          b.joinPath(meet),
+		Cost:          mu,
+		NodesExpanded: expanded,
+		Optimal:       true,
+		Bound:         1,
+	}, nil
+}
+
+// expand returns the nodes one move away from node in the search direction:
+// successors going forward, predecessors going backward.
+func (b *BidirectionalAStar) expand(node *Node, isForward bool) []*Node {
+	if !isForward {
+		return predecessors(b.grid, node)
+	}
+	return b.grid.GetNeighbors(node)
+}
+
+// joinPath builds the full path through the meeting node by following
+// forward parents back to the start and backward parents on to the goal.
+//
+// Params:
+//
+//	meet: node reached by both searches on the best path
+//
+// Returns:
+//
+//	[]*Node: path from start to goal
+func (b *BidirectionalAStar) joinPath(meet *Node) []*Node {
+	var path []*Node
+	for node := meet; node != nil; node = b.forward.parent[keyOf(node)] {
+		path = append(path, node)
+	}
+	reversePath(path)
+
+	for node := b.backward.parent[keyOf(meet)]; node != nil; node = b.backward.parent[keyOf(node)] {
+		path = append(path, node)
+	}
+
+	return path
+}
@@ -0,0 +1,177 @@
+package algo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestBidirectionalAStar_ImplementsPathfinder verifies interface compliance
+func TestBidirectionalAStar_ImplementsPathfinder(t *testing.T) {
+	var _ Pathfinder = NewBidirectionalAStar()
+}
+
+// TestBidirectionalAStar_FindPath_SimpleCase tests basic pathfinding
+func TestBidirectionalAStar_FindPath_SimpleCase(t *testing.T) {
+	grid, _ := NewGrid(5, 5, FourWay)
+	b := NewBidirectionalAStar()
+	b.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(4, 4)
+
+	path, err := b.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+
+	if !path[0].Equals(start) || !path[len(path)-1].Equals(goal) {
+		t.Errorf("Path should run from start to goal, got %v -> %v", path[0], path[len(path)-1])
+	}
+
+	if len(path) != 9 {
+		t.Errorf("Expected path length 9, got %d", len(path))
+	}
+
+	assertContiguousPath(t, grid, path)
+}
+
+// TestBidirectionalAStar_MatchesDijkstra verifies optimality on random weighted
+// grids. Grid costs depend on the destination node, so edges are directed.
+func TestBidirectionalAStar_MatchesDijkstra(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+
+	for trial := 0; trial < 20; trial++ {
+		movement := FourWay
+		heuristic := Manhattan
+		if trial%2 == 1 {
+			movement = EightWay
+			heuristic = Diagonal
+		}
+
+		grid, _ := NewGrid(25, 25, movement)
+		for x := 0; x < 25; x++ {
+			for y := 0; y < 25; y++ {
+				node, _ := grid.GetNode(x, y)
+				node.Cost = 1 + float64(rng.Intn(5))
+				if rng.Float64() < 0.2 {
+					node.IsObstacle = true
+				}
+			}
+		}
+
+		start, _ := grid.GetNode(0, 0)
+		goal, _ := grid.GetNode(24, 24)
+		start.IsObstacle = false
+		goal.IsObstacle = false
+
+		d := NewDijkstra()
+		d.SetGrid(grid)
+		dPath, dErr := d.FindPath(start, goal)
+
+		b := NewBidirectionalAStar()
+		b.SetGrid(grid)
+		b.SetHeuristic(heuristic)
+		result, bErr := b.Search(start, goal)
+
+		if (dErr == nil) != (bErr == nil) {
+			t.Fatalf("trial %d: Dijkstra err=%v, bidirectional err=%v", trial, dErr, bErr)
+		}
+		if dErr != nil {
+			continue
+		}
+
+		dCost := PathCost(grid, dPath)
+		if math.Abs(result.Cost-dCost) > 1e-9 {
+			t.Errorf("trial %d: bidirectional cost %.3f differs from Dijkstra cost %.3f", trial, result.Cost, dCost)
+		}
+
+		if math.Abs(PathCost(grid, result.Path)-result.Cost) > 1e-9 {
+			t.Errorf("trial %d: path cost %.3f does not match reported cost %.3f",
+				trial, PathCost(grid, result.Path), result.Cost)
+		}
+
+		assertContiguousPath(t, grid, result.Path)
+	}
+}
+
+// TestBidirectionalAStar_DirectedCosts tests a map where the cheap direction
+// of travel differs, so forward and backward costs are asymmetric
+func TestBidirectionalAStar_DirectedCosts(t *testing.T) {
+	// Entering the middle row is expensive, leaving it is cheap
+	grid, _ := NewGrid(6, 3, FourWay)
+	for x := 1; x < 5; x++ {
+		node, _ := grid.GetNode(x, 1)
+		node.Cost = 4.0
+	}
+
+	start, _ := grid.GetNode(0, 1)
+	goal, _ := grid.GetNode(5, 1)
+
+	b := NewBidirectionalAStar()
+	b.SetGrid(grid)
+	result, err := b.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search() returned error: %v", err)
+	}
+
+	// Detour through row 0: up, 5 across, down = 7
+	if result.Cost != 7.0 {
+		t.Errorf("Expected cost 7.0, got %.2f", result.Cost)
+	}
+
+	if !result.Optimal || result.Bound != 1 {
+		t.Error("Bidirectional A* results should be reported optimal")
+	}
+}
+
+// TestBidirectionalAStar_FindPath_NoPath tests when no path exists
+func TestBidirectionalAStar_FindPath_NoPath(t *testing.T) {
+	grid, _ := NewGrid(5, 5, FourWay)
+	for y := 0; y < 5; y++ {
+		grid.SetObstacle(2, y)
+	}
+
+	b := NewBidirectionalAStar()
+	b.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 2)
+	goal, _ := grid.GetNode(4, 2)
+
+	if path, err := b.FindPath(start, goal); err == nil || path != nil {
+		t.Error("FindPath() should return nil path and error when no path exists")
+	}
+}
+
+// TestBidirectionalAStar_FindPath_InputValidation tests error handling for invalid inputs
+func TestBidirectionalAStar_FindPath_InputValidation(t *testing.T) {
+	b := NewBidirectionalAStar()
+	grid, _ := NewGrid(5, 5, FourWay)
+	node, _ := grid.GetNode(0, 0)
+	other, _ := grid.GetNode(1, 0)
+
+	if _, err := b.FindPath(nil, node); err == nil {
+		t.Error("FindPath() should return error for nil start node")
+	}
+
+	if _, err := b.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when grid is not set")
+	}
+
+	b.SetGrid(grid)
+	b.SetHeuristic(nil)
+	if _, err := b.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when heuristic is nil")
+	}
+
+	b.SetHeuristic(Manhattan)
+	path, err := b.FindPath(node, other)
+	if err != nil || len(path) != 2 {
+		t.Errorf("Expected two-node path for adjacent nodes, got %v (err=%v)", path, err)
+	}
+
+	path, err = b.FindPath(node, node)
+	if err != nil || len(path) != 1 {
+		t.Errorf("Expected single-node path for same start/goal, got %v (err=%v)", path, err)
+	}
+}
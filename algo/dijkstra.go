@@ -0,0 +1,131 @@
+package algo
+
+import (
+	"fmt"
+)
+
+// Dijkstra implements Dijkstra's uniform-cost search.
+// Unlike A*, it uses no heuristic: nodes are expanded strictly in order of
+// their cost from the start (g-cost), which guarantees optimal paths on any
+// grid with non-negative movement costs.
+//
+// Dijkstra is mainly useful as a reference oracle for other pathfinders and
+// as a baseline on weighted maps where no good heuristic exists.
+type Dijkstra struct {
+	// grid is the search space we're pathfinding within
+	grid SearchSpace
+
+	// openSet contains nodes to be evaluated, ordered by g-cost
+	openSet *PriorityQueue
+
+	// closedSet contains nodes already evaluated (coordinates -> bool)
+	closedSet map[nodeKey]bool
+}
+
+// NewDijkstra creates a new Dijkstra pathfinder instance.
+//
+// Returns:
+//
+//	*Dijkstra: new Dijkstra pathfinder instance
+func NewDijkstra() *Dijkstra {
+	return &Dijkstra{
+		openSet:   NewPriorityQueue(),
+		closedSet: make(map[nodeKey]bool),
+	}
+}
+
+// SetGrid configures the grid/search space for the pathfinder.
+// This must be called before FindPath.
+//
+// Params:
+//
+//	grid: grid to search within
+func (d *Dijkstra) SetGrid(grid SearchSpace) {
+	d.grid = grid
+}
+
+// SetHeuristic is a no-op for Dijkstra.
+// Uniform-cost search never consults a heuristic; the method exists only
+// so Dijkstra satisfies the Pathfinder interface.
+//
+// Params:
+//
+//	heuristic: ignored
+func (d *Dijkstra) SetHeuristic(heuristic HeuristicFunc) {}
+
+// FindPath finds the lowest-cost path from start to goal using Dijkstra's algorithm.
+// Returns the complete path including start and goal nodes, or an error if no path exists.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	[]*Node: path from start to goal (including both endpoints)
+//	error: if no path exists or invalid input
+func (d *Dijkstra) FindPath(start, goal *Node) ([]*Node, error) {
+	if err := validateEndpoints(d.grid, start, goal); err != nil {
+		return nil, err
+	}
+
+	// If start equals goal, return single-node path
+	if start.Equals(goal) {
+		return []*Node{start}, nil
+	}
+
+	d.reset()
+
+	// F mirrors G so the shared priority queue orders by cost from start
+	start.G = 0
+	start.H = 0
+	start.CalculateF()
+	start.Parent = nil
+	d.openSet.PushNode(start)
+
+	for !d.openSet.IsEmpty() {
+		current := d.openSet.PopNode()
+
+		// The first time the goal is popped its cost is final
+		if current.Equals(goal) {
+			return reconstructPath(current), nil
+		}
+
+		d.closedSet[keyOf(current)] = true
+
+		for _, neighbor := range d.grid.GetNeighbors(current) {
+			if neighbor.IsObstacle || d.closedSet[keyOf(neighbor)] {
+				continue
+			}
+
+			newG := current.G + d.grid.GetCost(current, neighbor)
+			inOpenSet := d.openSet.ContainsNode(neighbor)
+
+			if !inOpenSet || newG < neighbor.G {
+				neighbor.G = newG
+				neighbor.H = 0
+				neighbor.CalculateF()
+				neighbor.Parent = current
+
+				if !inOpenSet {
+					d.openSet.PushNode(neighbor)
+				} else {
+					d.openSet.UpdateNodePriority(neighbor, neighbor.F)
+				}
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+		start.X, start.Y, goal.X, goal.Y)
+}
+
+// reset clears the algorithm state for a fresh pathfinding operation.
+func (d *Dijkstra) reset() {
+	d.openSet.Clear()
+	d.closedSet = make(map[nodeKey]bool)
+	if d.grid != nil {
+		d.grid.Reset()
+	}
+}
@@ -0,0 +1,203 @@
+package algo
+
+import (
+	"math"
+	"testing"
+)
+
+// TestDijkstra_NewDijkstra tests the constructor
+func TestDijkstra_NewDijkstra(t *testing.T) {
+	d := NewDijkstra()
+
+	if d == nil {
+		t.Fatal("NewDijkstra() returned nil")
+	}
+
+	if d.openSet == nil {
+		t.Error("openSet should be initialized")
+	}
+
+	if d.closedSet == nil {
+		t.Error("closedSet should be initialized")
+	}
+}
+
+// TestDijkstra_ImplementsPathfinder verifies interface compliance
+func TestDijkstra_ImplementsPathfinder(t *testing.T) {
+	var _ Pathfinder = NewDijkstra()
+}
+
+// TestDijkstra_SetHeuristicIsNoOp verifies heuristics do not affect results
+func TestDijkstra_SetHeuristicIsNoOp(t *testing.T) {
+	grid, _ := NewGrid(6, 6, FourWay)
+	d := NewDijkstra()
+	d.SetGrid(grid)
+
+	// A wildly inadmissible heuristic would break optimality if it were used
+	d.SetHeuristic(func(current, goal *Node) float64 { return 1000 * EuclideanSquared(current, goal) })
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(5, 5)
+
+	path, err := d.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+
+	if len(path) != 11 {
+		t.Errorf("Expected path length 11, got %d", len(path))
+	}
+}
+
+// TestDijkstra_FindPath_SimpleCase tests basic pathfinding
+func TestDijkstra_FindPath_SimpleCase(t *testing.T) {
+	grid, _ := NewGrid(5, 5, FourWay)
+	d := NewDijkstra()
+	d.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(4, 4)
+
+	path, err := d.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+
+	if !path[0].Equals(start) || !path[len(path)-1].Equals(goal) {
+		t.Errorf("Path should run from start to goal, got %v -> %v", path[0], path[len(path)-1])
+	}
+
+	if len(path) != 9 {
+		t.Errorf("Expected path length 9, got %d", len(path))
+	}
+}
+
+// TestDijkstra_FindPath_WeightedGrid tests that expensive terrain is avoided
+func TestDijkstra_FindPath_WeightedGrid(t *testing.T) {
+	grid, _ := NewGrid(5, 3, FourWay)
+
+	// Make the direct middle row expensive
+	for x := 1; x < 4; x++ {
+		node, _ := grid.GetNode(x, 1)
+		node.Cost = 10.0
+	}
+
+	d := NewDijkstra()
+	d.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 1)
+	goal, _ := grid.GetNode(4, 1)
+
+	path, err := d.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+
+	// Detour via row 0 or 2: up, 4 across, down = 6 moves
+	if cost := PathCost(grid, path); cost != 6.0 {
+		t.Errorf("Expected path cost 6.0, got %.2f", cost)
+	}
+}
+
+// TestDijkstra_MatchesAStar verifies Dijkstra and A* agree on optimal cost
+func TestDijkstra_MatchesAStar(t *testing.T) {
+	tests := []struct {
+		name         string
+		movementType MovementType
+		heuristic    HeuristicFunc
+	}{
+		{"4-way Manhattan", FourWay, Manhattan},
+		{"8-way Octile", EightWay, Diagonal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid, _ := NewGrid(20, 20, tt.movementType)
+			for x := 0; x < 20; x++ {
+				for y := 0; y < 20; y++ {
+					node, _ := grid.GetNode(x, y)
+					node.Cost = float64(1 + (x*7+y*13)%4)
+					if x%5 == 2 && y != (x*3)%20 {
+						node.IsObstacle = true
+					}
+				}
+			}
+
+			start, _ := grid.GetNode(0, 0)
+			goal, _ := grid.GetNode(19, 19)
+
+			d := NewDijkstra()
+			d.SetGrid(grid)
+			dPath, err := d.FindPath(start, goal)
+			if err != nil {
+				t.Fatalf("Dijkstra FindPath() returned error: %v", err)
+			}
+			dCost := PathCost(grid, dPath)
+
+			a := NewAStar()
+			a.SetGrid(grid)
+			a.SetHeuristic(tt.heuristic)
+			aPath, err := a.FindPath(start, goal)
+			if err != nil {
+				t.Fatalf("AStar FindPath() returned error: %v", err)
+			}
+			aCost := PathCost(grid, aPath)
+
+			if math.Abs(dCost-aCost) > 1e-9 {
+				t.Errorf("Dijkstra cost %.3f differs from A* cost %.3f", dCost, aCost)
+			}
+		})
+	}
+}
+
+// TestDijkstra_FindPath_NoPath tests when no path exists
+func TestDijkstra_FindPath_NoPath(t *testing.T) {
+	grid, _ := NewGrid(5, 5, FourWay)
+	for y := 0; y < 5; y++ {
+		grid.SetObstacle(2, y)
+	}
+
+	d := NewDijkstra()
+	d.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 2)
+	goal, _ := grid.GetNode(4, 2)
+
+	path, err := d.FindPath(start, goal)
+	if err == nil {
+		t.Fatal("FindPath() should return error when no path exists")
+	}
+
+	if path != nil {
+		t.Error("FindPath() should return nil path when no path exists")
+	}
+}
+
+// TestDijkstra_FindPath_InputValidation tests error handling for invalid inputs
+func TestDijkstra_FindPath_InputValidation(t *testing.T) {
+	d := NewDijkstra()
+	grid, _ := NewGrid(5, 5, FourWay)
+	node, _ := grid.GetNode(0, 0)
+
+	if _, err := d.FindPath(nil, node); err == nil {
+		t.Error("FindPath() should return error for nil start node")
+	}
+
+	if _, err := d.FindPath(node, node); err == nil {
+		t.Error("FindPath() should return error when grid is not set")
+	}
+
+	d.SetGrid(grid)
+
+	goal, _ := grid.GetNode(2, 2)
+	goal.IsObstacle = true
+	if _, err := d.FindPath(node, goal); err == nil {
+		t.Error("FindPath() should return error when goal is obstacle")
+	}
+
+	goal.IsObstacle = false
+	path, err := d.FindPath(goal, goal)
+	if err != nil || len(path) != 1 {
+		t.Errorf("Expected single-node path for same start/goal, got %v (err=%v)", path, err)
+	}
+}
@@ -0,0 +1,325 @@
+package algo
+
+import (
+	"fmt"
+	"math"
+)
+
+// DStarLite implements the D* Lite incremental replanning algorithm
+// (Koenig & Likhachev, 2002) for agents moving through a changing grid.
+//
+// D* Lite searches backward from the goal and keeps its search state (g and
+// rhs values) between calls. When the agent moves or cells change, only the
+// affected part of the search is repaired instead of planning from scratch.
+//
+// Typical usage:
+//
+//	planner := NewDStarLite()
+//	planner.SetGrid(grid)
+//	path, err := planner.FindPath(start, goal) // initial plan
+//	...
+//	planner.MoveTo(path[1])                   // agent takes a step
+//	grid.SetObstacle(x, y)                    // world changes
+//	planner.NotifyChanged(changedNode)        // tell the planner
+//	path, err = planner.Replan()              // repaired path from current position
+//
+// On directed search spaces such as Graph, predecessors are taken from
+// GetPredecessors; otherwise moves are assumed to be symmetric, as they are
+// for Grid.
+// Search state is stored in the planner, not in Node fields, so other
+// pathfinders may share the grid between replans.
+type DStarLite struct {
+	// grid is the search space we're pathfinding within
+	grid SearchSpace
+
+	// heuristic function estimates cost between two nodes
+	heuristic HeuristicFunc
+
+	// start is the agent's current position, goal the fixed destination
+	start, goal *Node
+
+	// last is the start position when km was last updated
+	last *Node
+
+	// km accumulates heuristic offsets caused by agent movement
+	km float64
+
+	// incrementalState holds cost-to-goal estimates and the open set
+	incrementalState
+
+	// expanded counts nodes expanded by the most recent replan
+	expanded int
+}
+
+// NewDStarLite creates a new D* Lite planner instance.
+// By default, it uses the Manhattan heuristic.
+//
+// Returns:
+//
+//	*DStarLite: new D* Lite planner instance
+func NewDStarLite() *DStarLite {
+	return &DStarLite{
+		heuristic:        Manhattan,
+		incrementalState: newIncrementalState(),
+	}
+}
+
+// SetGrid configures the grid/search space for the planner.
+// Changing the grid discards any existing plan.
+//
+// Params:
+//
+//	grid: grid to search within
+func (d *DStarLite) SetGrid(grid SearchSpace) {
+	d.grid = grid
+	d.goal = nil
+}
+
+// SetHeuristic configures the heuristic function for the planner.
+// The heuristic must be admissible and consistent. Changing the heuristic
+// discards any existing plan.
+//
+// Params:
+//
+//	heuristic: heuristic function to use
+func (d *DStarLite) SetHeuristic(heuristic HeuristicFunc) {
+	d.heuristic = heuristic
+	d.goal = nil
+}
+
+// FindPath plans a new path from start to goal, discarding any previous
+// search state. Use MoveTo, NotifyChanged and Replan to repair this plan
+// incrementally afterwards.
+//
+// Params:
+//
+//	start: agent's starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	[]*Node: path from start to goal (including both endpoints)
+//	error: if no path exists or invalid input
+func (d *DStarLite) FindPath(start, goal *Node) ([]*Node, error) {
+	if err := validateEndpoints(d.grid, start, goal); err != nil {
+		return nil, err
+	}
+
+	if d.heuristic == nil {
+		return nil, fmt.Errorf("heuristic must be set before calling FindPath")
+	}
+
+	d.start, d.last, d.goal = start, start, goal
+	d.km = 0
+	d.reset()
+
+	d.setRHS(goal, 0)
+	d.insert(goal)
+
+	return d.Replan()
+}
+
+// MoveTo updates the agent's current position. Subsequent calls to Replan
+// return paths starting from this node.
+//
+// Params:
+//
+//	node: agent's new position
+//
+// Returns:
+//
+//	error: if no plan exists yet or node is nil or an obstacle
+func (d *DStarLite) MoveTo(node *Node) error {
+	if d.goal == nil {
+		return fmt.Errorf("FindPath must be called before MoveTo")
+	}
+
+	if node == nil {
+		return fmt.Errorf("node cannot be nil")
+	}
+
+	if node.IsObstacle {
+		return fmt.Errorf("cannot move to obstacle at (%d, %d)", node.X, node.Y)
+	}
+
+	d.start = node
+	return nil
+}
+
+// NotifyChanged informs the planner that the given cells changed, e.g. after
+// Grid.SetObstacle, Grid.ClearObstacle or editing Node.Cost. Only these cells
+// and their predecessors are updated; the repair happens on the next Replan.
+//
+// Params:
+//
+//	nodes: cells whose obstacle status or cost changed
+//
+// Returns:
+//
+//	error: if no plan exists yet
+func (d *DStarLite) NotifyChanged(nodes ...*Node) error {
+	if d.goal == nil {
+		return fmt.Errorf("FindPath must be called before NotifyChanged")
+	}
+
+	d.syncStart()
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		d.updateVertex(node)
+		for _, pred := range predecessors(d.grid, node) {
+			d.updateVertex(pred)
+		}
+	}
+
+	return nil
+}
+
+// Replan repairs the search after movement and cell changes and returns the
+// current best path from the agent's position to the goal.
+//
+// Returns:
+//
+//	[]*Node: path from the current position to goal (including both endpoints)
+//	error: if no plan exists yet, the agent is on an obstacle, or the goal is unreachable
+func (d *DStarLite) Replan() ([]*Node, error) {
+	if d.goal == nil {
+		return nil, fmt.Errorf("FindPath must be called before Replan")
+	}
+
+	if d.start.IsObstacle {
+		return nil, fmt.Errorf("start node at (%d, %d) is an obstacle", d.start.X, d.start.Y)
+	}
+
+	if d.goal.IsObstacle {
+		return nil, fmt.Errorf("goal node at (%d, %d) is an obstacle", d.goal.X, d.goal.Y)
+	}
+
+	d.syncStart()
+	d.computeShortestPath()
+
+	if math.IsInf(d.getG(d.start), 1) {
+		return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+			d.start.X, d.start.Y, d.goal.X, d.goal.Y)
+	}
+
+	return d.extractPath()
+}
+
+// NodesExpanded returns the number of nodes expanded by the most recent
+// FindPath or Replan. Small values after a change show that only the
+// affected region was repaired.
+//
+// Returns:
+//
+//	int: number of expanded nodes
+func (d *DStarLite) NodesExpanded() int {
+	return d.expanded
+}
+
+// syncStart accounts for agent movement since the last update. Heuristic
+// values shift as the start moves; adding the distance moved to km keeps
+// previously queued keys valid lower bounds.
+func (d *DStarLite) syncStart() {
+	if d.last.Equals(d.start) {
+		return
+	}
+	d.km += d.heuristic(d.last, d.start)
+	d.last = d.start
+}
+
+// computeShortestPath expands inconsistent nodes until the start node is
+// consistent and no queued node could improve it.
+func (d *DStarLite) computeShortestPath() {
+	d.expanded = 0
+
+	for {
+		top, ok := d.topKey()
+		if !ok {
+			return
+		}
+		if !top.less(d.calculateKey(d.start)) && d.consistent(d.start) {
+			return
+		}
+
+		u, oldKey := d.pop()
+		d.expanded++
+
+		newKey := d.calculateKey(u)
+
+		switch {
+		case oldKey.less(newKey):
+			// Key was outdated by agent movement; requeue with the current key
+			d.insert(u)
+		case d.getG(u) > d.getRHS(u):
+			// Overconsistent: settle g and propagate to predecessors
+			d.setG(u, d.getRHS(u))
+			for _, pred := range predecessors(d.grid, u) {
+				d.updateVertex(pred)
+			}
+		default:
+			// Underconsistent: invalidate g and recompute u and its predecessors
+			d.setG(u, math.Inf(1))
+			d.updateVertex(u)
+			for _, pred := range predecessors(d.grid, u) {
+				d.updateVertex(pred)
+			}
+		}
+	}
+}
+
+// updateVertex recomputes rhs(u) from its successors and (re)queues u if it
+// is inconsistent.
+func (d *DStarLite) updateVertex(u *Node) {
+	if !u.Equals(d.goal) {
+		_, best := d.cheapest(d.grid.GetNeighbors(u), func(succ *Node) float64 {
+			return d.cost(u, succ)
+		})
+		d.setRHS(u, best)
+	}
+	d.requeue(u, d.calculateKey(u))
+}
+
+// extractPath follows the cheapest successor from the start to the goal.
+func (d *DStarLite) extractPath() ([]*Node, error) {
+	path := []*Node{d.start}
+	visited := map[nodeKey]bool{keyOf(d.start): true}
+
+	for current := d.start; !current.Equals(d.goal); {
+		next, _ := d.cheapest(d.grid.GetNeighbors(current), func(succ *Node) float64 {
+			return d.cost(current, succ)
+		})
+
+		if next == nil || visited[keyOf(next)] {
+			return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+				d.start.X, d.start.Y, d.goal.X, d.goal.Y)
+		}
+
+		visited[keyOf(next)] = true
+		path = append(path, next)
+		current = next
+	}
+
+	return path, nil
+}
+
+// cost returns the movement cost between two adjacent nodes, or +Inf if
+// either is an obstacle.
+func (d *DStarLite) cost(from, to *Node) float64 {
+	if from.IsObstacle || to.IsObstacle {
+		return math.Inf(1)
+	}
+	return d.grid.GetCost(from, to)
+}
+
+// calculateKey computes the priority of a node.
+func (d *DStarLite) calculateKey(node *Node) dstarKey {
+	m := math.Min(d.getG(node), d.getRHS(node))
+	return dstarKey{m + d.heuristic(node, d.start) + d.km, m}
+}
+
+// insert queues a node with its current key.
+func (d *DStarLite) insert(node *Node) {
+	d.enqueue(node, d.calculateKey(node))
+}
@@ -0,0 +1,248 @@
+package algo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestDStarLite_ImplementsPathfinder verifies interface compliance
+func TestDStarLite_ImplementsPathfinder(t *testing.T) {
+	var _ Pathfinder = NewDStarLite()
+}
+
+// TestDStarLite_FindPath_SimpleCase tests the initial plan
+func TestDStarLite_FindPath_SimpleCase(t *testing.T) {
+	grid, _ := NewGrid(5, 5, FourWay)
+	d := NewDStarLite()
+	d.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(4, 4)
+
+	path, err := d.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+
+	if len(path) != 9 {
+		t.Errorf("Expected path length 9, got %d", len(path))
+	}
+
+	assertContiguousPath(t, grid, path)
+}
+
+// TestDStarLite_Replan_BlockedRoute tests repairing the plan when an
+// obstacle appears on the route ahead of the agent
+func TestDStarLite_Replan_BlockedRoute(t *testing.T) {
+	grid, _ := NewGrid(7, 5, FourWay)
+	// Wall with a single gap at the top
+	for y := 1; y < 5; y++ {
+		grid.SetObstacle(3, y)
+	}
+
+	d := NewDStarLite()
+	d.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 4)
+	goal, _ := grid.GetNode(6, 4)
+
+	path, err := d.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+
+	// Agent steps forward, then the gap moves from the top to the bottom
+	if err := d.MoveTo(path[1]); err != nil {
+		t.Fatalf("MoveTo() returned error: %v", err)
+	}
+
+	grid.SetObstacle(3, 0)
+	grid.ClearObstacle(3, 4)
+	closed, _ := grid.GetNode(3, 0)
+	opened, _ := grid.GetNode(3, 4)
+	if err := d.NotifyChanged(closed, opened); err != nil {
+		t.Fatalf("NotifyChanged() returned error: %v", err)
+	}
+
+	repaired, err := d.Replan()
+	if err != nil {
+		t.Fatalf("Replan() returned error: %v", err)
+	}
+
+	if !repaired[0].Equals(path[1]) {
+		t.Errorf("Repaired path should start at agent position %v, got %v", path[1], repaired[0])
+	}
+
+	dijkstra := NewDijkstra()
+	dijkstra.SetGrid(grid)
+	expected, err := dijkstra.FindPath(path[1], goal)
+	if err != nil {
+		t.Fatalf("Dijkstra FindPath() returned error: %v", err)
+	}
+
+	if len(repaired) != len(expected) {
+		t.Errorf("Expected repaired path length %d, got %d", len(expected), len(repaired))
+	}
+
+	assertContiguousPath(t, grid, repaired)
+}
+
+// TestDStarLite_Replan_MatchesDijkstra walks an agent along its plan while
+// random cells change, checking every repaired path is optimal
+func TestDStarLite_Replan_MatchesDijkstra(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+
+	for _, movement := range []MovementType{FourWay, EightWay} {
+		grid, _ := NewGrid(20, 20, movement)
+		for x := 0; x < 20; x++ {
+			for y := 0; y < 20; y++ {
+				if rng.Float64() < 0.15 {
+					grid.SetObstacle(x, y)
+				}
+			}
+		}
+
+		start, _ := grid.GetNode(0, 0)
+		goal, _ := grid.GetNode(19, 19)
+		grid.ClearObstacle(0, 0)
+		grid.ClearObstacle(19, 19)
+
+		d := NewDStarLite()
+		d.SetGrid(grid)
+		d.SetHeuristic(Diagonal)
+
+		path, err := d.FindPath(start, goal)
+		for step := 0; err == nil && len(path) > 1; step++ {
+			agent := path[1]
+			if err := d.MoveTo(agent); err != nil {
+				t.Fatalf("MoveTo() returned error: %v", err)
+			}
+
+			// Toggle a few random cells, never the agent or goal
+			var changed []*Node
+			for i := 0; i < 3; i++ {
+				node, _ := grid.GetNode(rng.Intn(20), rng.Intn(20))
+				if node.Equals(agent) || node.Equals(goal) {
+					continue
+				}
+				node.IsObstacle = !node.IsObstacle
+				changed = append(changed, node)
+			}
+			d.NotifyChanged(changed...)
+
+			path, err = d.Replan()
+
+			dijkstra := NewDijkstra()
+			dijkstra.SetGrid(grid)
+			expected, dErr := dijkstra.FindPath(agent, goal)
+
+			if (err == nil) != (dErr == nil) {
+				t.Fatalf("step %d: D* Lite err=%v, Dijkstra err=%v", step, err, dErr)
+			}
+			if err != nil {
+				break
+			}
+
+			if math.Abs(PathCost(grid, path)-PathCost(grid, expected)) > 1e-9 {
+				t.Errorf("step %d: D* Lite cost %.3f differs from Dijkstra cost %.3f",
+					step, PathCost(grid, path), PathCost(grid, expected))
+			}
+			assertContiguousPath(t, grid, path)
+		}
+	}
+}
+
+// TestDStarLite_Replan_Incremental tests that a local change re-expands far
+// fewer nodes than the initial search
+func TestDStarLite_Replan_Incremental(t *testing.T) {
+	grid, _ := NewGrid(50, 50, FourWay)
+	d := NewDStarLite()
+	d.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(49, 49)
+
+	if _, err := d.FindPath(start, goal); err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+	initial := d.NodesExpanded()
+
+	// Block a cell far from the current route's critical region
+	grid.SetObstacle(45, 2)
+	blocked, _ := grid.GetNode(45, 2)
+	d.NotifyChanged(blocked)
+
+	if _, err := d.Replan(); err != nil {
+		t.Fatalf("Replan() returned error: %v", err)
+	}
+
+	if d.NodesExpanded() >= initial {
+		t.Errorf("Replan expanded %d nodes, expected fewer than initial %d", d.NodesExpanded(), initial)
+	}
+}
+
+// TestDStarLite_Replan_NoPath tests when a change disconnects the goal
+func TestDStarLite_Replan_NoPath(t *testing.T) {
+	grid, _ := NewGrid(5, 5, FourWay)
+	d := NewDStarLite()
+	d.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 2)
+	goal, _ := grid.GetNode(4, 2)
+
+	if _, err := d.FindPath(start, goal); err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+
+	var wall []*Node
+	for y := 0; y < 5; y++ {
+		grid.SetObstacle(2, y)
+		node, _ := grid.GetNode(2, y)
+		wall = append(wall, node)
+	}
+	d.NotifyChanged(wall...)
+
+	if path, err := d.Replan(); err == nil || path != nil {
+		t.Error("Replan() should return nil path and error when goal is cut off")
+	}
+}
+
+// TestDStarLite_InputValidation tests error handling for invalid inputs
+func TestDStarLite_InputValidation(t *testing.T) {
+	d := NewDStarLite()
+	grid, _ := NewGrid(5, 5, FourWay)
+	node, _ := grid.GetNode(0, 0)
+
+	if _, err := d.Replan(); err == nil {
+		t.Error("Replan() should return error before FindPath")
+	}
+
+	if err := d.MoveTo(node); err == nil {
+		t.Error("MoveTo() should return error before FindPath")
+	}
+
+	if err := d.NotifyChanged(node); err == nil {
+		t.Error("NotifyChanged() should return error before FindPath")
+	}
+
+	if _, err := d.FindPath(node, node); err == nil {
+		t.Error("FindPath() should return error when grid is not set")
+	}
+
+	d.SetGrid(grid)
+	path, err := d.FindPath(node, node)
+	if err != nil || len(path) != 1 {
+		t.Errorf("Expected single-node path for same start/goal, got %v (err=%v)", path, err)
+	}
+
+	grid.SetObstacle(1, 0)
+	obstacle, _ := grid.GetNode(1, 0)
+	if err := d.MoveTo(obstacle); err == nil {
+		t.Error("MoveTo() should reject obstacles")
+	}
+
+	if err := d.MoveTo(nil); err == nil {
+		t.Error("MoveTo() should reject nil nodes")
+	}
+}
@@ -0,0 +1,195 @@
+package algo
+
+import (
+	"fmt"
+	"math"
+)
+
+// FlowField guides any number of agents toward a single shared goal.
+// It holds an integration field (the cheapest cost from every reachable
+// cell to the goal, computed once with Dijkstra's algorithm) and, for each
+// cell, the direction of the next step along a cheapest path. Agents then
+// look up their next move in O(1) instead of running a search each.
+//
+// Works with any GridInterface, including FourWay and EightWay grids and
+// weighted Node.Cost. Neighbor relations are assumed to be symmetric, as
+// they are for Grid. Call Recompute after the grid changes.
+type FlowField struct {
+	// grid is the search space the field covers
+	grid GridInterface
+
+	// goal is the cell all directions lead to
+	goal *Node
+
+	// integration maps reachable cells to their cost-to-goal
+	integration map[coordinate]float64
+
+	// next maps reachable cells (except the goal) to their next step
+	next map[coordinate]*Node
+}
+
+// NewFlowField computes the flow field toward goal over the given grid.
+//
+// Params:
+//
+//	grid: grid to cover
+//	goal: destination shared by all agents
+//
+// Returns:
+//
+//	*FlowField: computed flow field
+//	error: if grid or goal is nil, or the goal is an obstacle
+func NewFlowField(grid GridInterface, goal *Node) (*FlowField, error) {
+	if grid == nil {
+		return nil, fmt.Errorf("grid cannot be nil")
+	}
+
+	if goal == nil {
+		return nil, fmt.Errorf("goal node cannot be nil")
+	}
+
+	f := &FlowField{grid: grid, goal: goal}
+	if err := f.Recompute(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Recompute rebuilds the field after obstacles or costs in the grid changed.
+//
+// Returns:
+//
+//	error: if the goal has become an obstacle
+func (f *FlowField) Recompute() error {
+	if f.goal.IsObstacle {
+		return fmt.Errorf("goal node at (%d, %d) is an obstacle", f.goal.X, f.goal.Y)
+	}
+
+	goalCoord := coordinate{f.goal.X, f.goal.Y}
+	f.integration = map[coordinate]float64{goalCoord: 0}
+	f.next = make(map[coordinate]*Node)
+	settled := make(map[coordinate]bool)
+
+	// Dijkstra backward from the goal: relaxing u's neighbor p means moving p -> u
+	var open entryQueue
+	open.push(f.goal, 0, 0)
+
+	for open.Len() > 0 {
+		entry := open.pop()
+		u := entry.node
+		coord := coordinate{u.X, u.Y}
+		if settled[coord] {
+			continue
+		}
+		settled[coord] = true
+
+		for _, p := range f.grid.GetNeighbors(u) {
+			pCoord := coordinate{p.X, p.Y}
+			if p.IsObstacle || settled[pCoord] {
+				continue
+			}
+
+			cost := entry.priority + f.grid.GetCost(p, u)
+			if old, seen := f.integration[pCoord]; seen && cost >= old {
+				continue
+			}
+			f.integration[pCoord] = cost
+			f.next[pCoord] = u
+			open.push(p, cost, 0)
+		}
+	}
+
+	return nil
+}
+
+// Goal returns the cell the field leads to.
+//
+// Returns:
+//
+//	*Node: goal node
+func (f *FlowField) Goal() *Node {
+	return f.goal
+}
+
+// Cost returns the integration value at (x, y): the cost of the cheapest
+// path from that cell to the goal.
+//
+// Params:
+//
+//	x, y: cell coordinates
+//
+// Returns:
+//
+//	float64: cost to goal, or +Inf if the cell cannot reach the goal
+func (f *FlowField) Cost(x, y int) float64 {
+	if cost, ok := f.integration[coordinate{x, y}]; ok {
+		return cost
+	}
+	return math.Inf(1)
+}
+
+// Direction returns the step an agent at (x, y) should take, as offsets to
+// add to its coordinates. At the goal the direction is (0, 0).
+//
+// Params:
+//
+//	x, y: cell coordinates
+//
+// Returns:
+//
+//	int, int: x and y offsets of the next step
+//	bool: false if the cell cannot reach the goal
+func (f *FlowField) Direction(x, y int) (int, int, bool) {
+	if x == f.goal.X && y == f.goal.Y {
+		return 0, 0, true
+	}
+
+	next, ok := f.next[coordinate{x, y}]
+	if !ok {
+		return 0, 0, false
+	}
+	return next.X - x, next.Y - y, true
+}
+
+// Next returns the node an agent standing on node should move to.
+//
+// Params:
+//
+//	node: agent's current cell
+//
+// Returns:
+//
+//	*Node: next cell, or nil at the goal or if the goal is unreachable
+func (f *FlowField) Next(node *Node) *Node {
+	if node == nil {
+		return nil
+	}
+	return f.next[coordinate{node.X, node.Y}]
+}
+
+// PathFrom follows the field from start to the goal.
+//
+// Params:
+//
+//	start: starting cell
+//
+// Returns:
+//
+//	[]*Node: path from start to goal (including both endpoints)
+//	error: if start is nil or cannot reach the goal
+func (f *FlowField) PathFrom(start *Node) ([]*Node, error) {
+	if start == nil {
+		return nil, fmt.Errorf("start node cannot be nil")
+	}
+
+	if _, ok := f.integration[coordinate{start.X, start.Y}]; !ok {
+		return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+			start.X, start.Y, f.goal.X, f.goal.Y)
+	}
+
+	path := []*Node{start}
+	for current := f.Next(start); current != nil; current = f.Next(current) {
+		path = append(path, current)
+	}
+	return path, nil
+}
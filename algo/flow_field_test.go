@@ -0,0 +1,162 @@
+package algo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestNewFlowField_InputValidation tests error handling for invalid inputs
+func TestNewFlowField_InputValidation(t *testing.T) {
+	grid, _ := NewGrid(5, 5, FourWay)
+	goal, _ := grid.GetNode(2, 2)
+
+	if _, err := NewFlowField(nil, goal); err == nil {
+		t.Error("NewFlowField() should return error for nil grid")
+	}
+
+	if _, err := NewFlowField(grid, nil); err == nil {
+		t.Error("NewFlowField() should return error for nil goal")
+	}
+
+	grid.SetObstacle(2, 2)
+	if _, err := NewFlowField(grid, goal); err == nil {
+		t.Error("NewFlowField() should return error when goal is an obstacle")
+	}
+}
+
+// TestFlowField_FourWay tests integration values and directions on a simple grid
+func TestFlowField_FourWay(t *testing.T) {
+	grid, _ := NewGrid(5, 5, FourWay)
+	goal, _ := grid.GetNode(4, 4)
+
+	field, err := NewFlowField(grid, goal)
+	if err != nil {
+		t.Fatalf("NewFlowField() returned error: %v", err)
+	}
+
+	if field.Goal() != goal {
+		t.Error("Goal() should return the goal node")
+	}
+
+	if cost := field.Cost(0, 0); cost != 8 {
+		t.Errorf("Expected cost 8 at (0, 0), got %.3f", cost)
+	}
+
+	if dx, dy, ok := field.Direction(4, 4); !ok || dx != 0 || dy != 0 {
+		t.Errorf("Expected (0, 0) direction at goal, got (%d, %d, %v)", dx, dy, ok)
+	}
+
+	dx, dy, ok := field.Direction(4, 0)
+	if !ok || dx != 0 || dy != 1 {
+		t.Errorf("Expected direction (0, 1) at (4, 0), got (%d, %d, %v)", dx, dy, ok)
+	}
+
+	if field.Next(goal) != nil {
+		t.Error("Next() at goal should return nil")
+	}
+}
+
+// TestFlowField_MatchesDijkstra tests that every cell's integration value
+// equals the optimal path cost and that following the field achieves it
+func TestFlowField_MatchesDijkstra(t *testing.T) {
+	for _, movement := range []MovementType{FourWay, EightWay} {
+		rng := rand.New(rand.NewSource(3))
+		grid, _ := NewGrid(15, 12, movement)
+		for y := 0; y < 12; y++ {
+			for x := 0; x < 15; x++ {
+				node, _ := grid.GetNode(x, y)
+				node.Cost = float64(1 + rng.Intn(4))
+				if rng.Float64() < 0.2 {
+					node.IsObstacle = true
+				}
+			}
+		}
+		goal, _ := grid.GetNode(7, 6)
+		goal.IsObstacle = false
+
+		field, err := NewFlowField(grid, goal)
+		if err != nil {
+			t.Fatalf("NewFlowField() returned error: %v", err)
+		}
+
+		dijkstra := NewDijkstra()
+		dijkstra.SetGrid(grid)
+
+		for y := 0; y < 12; y++ {
+			for x := 0; x < 15; x++ {
+				start, _ := grid.GetNode(x, y)
+				if start.IsObstacle {
+					if !math.IsInf(field.Cost(x, y), 1) {
+						t.Errorf("Obstacle (%d, %d) should have infinite cost", x, y)
+					}
+					continue
+				}
+
+				optimalPath, optimalErr := dijkstra.FindPath(start, goal)
+				path, err := field.PathFrom(start)
+				if (err == nil) != (optimalErr == nil) {
+					t.Fatalf("movement %d, (%d, %d): flow field error %v, Dijkstra error %v",
+						movement, x, y, err, optimalErr)
+				}
+
+				if err != nil {
+					if _, _, ok := field.Direction(x, y); ok {
+						t.Errorf("Unreachable cell (%d, %d) should have no direction", x, y)
+					}
+					continue
+				}
+
+				optimal := PathCost(grid, optimalPath)
+				if math.Abs(field.Cost(x, y)-optimal) > 1e-9 {
+					t.Errorf("movement %d, (%d, %d): integration %.3f, optimal %.3f",
+						movement, x, y, field.Cost(x, y), optimal)
+				}
+
+				assertContiguousPath(t, grid, path)
+				if math.Abs(PathCost(grid, path)-optimal) > 1e-9 {
+					t.Errorf("movement %d, (%d, %d): followed path costs %.3f, optimal %.3f",
+						movement, x, y, PathCost(grid, path), optimal)
+				}
+			}
+		}
+	}
+}
+
+// TestFlowField_Recompute tests that the field reflects grid changes after Recompute
+func TestFlowField_Recompute(t *testing.T) {
+	grid, _ := NewGrid(5, 3, FourWay)
+	goal, _ := grid.GetNode(4, 1)
+	field, _ := NewFlowField(grid, goal)
+
+	if cost := field.Cost(0, 1); cost != 4 {
+		t.Fatalf("Expected cost 4 before wall, got %.3f", cost)
+	}
+
+	// Wall at x=2 with a gap at the top
+	grid.SetObstacle(2, 1)
+	grid.SetObstacle(2, 2)
+	if err := field.Recompute(); err != nil {
+		t.Fatalf("Recompute() returned error: %v", err)
+	}
+
+	if cost := field.Cost(0, 1); cost != 6 {
+		t.Errorf("Expected cost 6 around wall, got %.3f", cost)
+	}
+
+	grid.SetObstacle(2, 0)
+	field.Recompute()
+	start, _ := grid.GetNode(0, 1)
+	if _, err := field.PathFrom(start); err == nil {
+		t.Error("PathFrom() should fail when the goal is walled off")
+	}
+
+	if _, err := field.PathFrom(nil); err == nil {
+		t.Error("PathFrom() should return error for nil start")
+	}
+
+	grid.SetObstacle(4, 1)
+	if err := field.Recompute(); err == nil {
+		t.Error("Recompute() should return error once the goal is an obstacle")
+	}
+}
@@ -0,0 +1,358 @@
+package algo
+
+import (
+	"fmt"
+	"math"
+)
+
+// graphEdge is a directed edge stored in an adjacency list.
+type graphEdge struct {
+	// node is the other endpoint: the target for outgoing edges, the
+	// source for incoming ones
+	node *Node
+
+	// weight is the cost of traversing the edge
+	weight float64
+}
+
+// Graph represents a directed weighted graph such as a road network or a
+// waypoint graph.
+//
+// Nodes are identified by arbitrary integer IDs, stored in Node.ID, and may
+// optionally be placed at (x, y) coordinates for distance heuristics. Edges
+// are kept in adjacency lists, outgoing and incoming, so neighbors and
+// predecessors are found in time proportional to the node's degree.
+//
+// Graph implements SearchSpace, so AStar, Dijkstra, BFS, GreedyBestFirst,
+// IDAStar, ARAStar, BidirectionalAStar and KShortest search it directly.
+// Obstacle nodes are skipped by GetNeighbors like obstacle cells on a grid.
+type Graph struct {
+	// nodes maps IDs to nodes
+	nodes map[int]*Node
+
+	// order lists node IDs in insertion order for deterministic iteration
+	order []int
+
+	// out and in are the outgoing and incoming adjacency lists by node ID
+	out, in map[int][]graphEdge
+
+	// edgeCount is the number of directed edges
+	edgeCount int
+}
+
+// NewGraph creates a new empty graph.
+//
+// Returns:
+//
+//	*Graph: new graph instance
+func NewGraph() *Graph {
+	return &Graph{
+		nodes: make(map[int]*Node),
+		out:   make(map[int][]graphEdge),
+		in:    make(map[int][]graphEdge),
+	}
+}
+
+// AddNode adds a node without coordinates. Heuristics that use coordinates
+// cannot be used with such nodes; use Zero instead.
+//
+// Params:
+//
+//	id: unique node ID
+//
+// Returns:
+//
+//	*Node: the new node
+//	error: if a node with the same ID already exists
+func (g *Graph) AddNode(id int) (*Node, error) {
+	return g.AddNodeAt(id, 0, 0)
+}
+
+// AddNodeAt adds a node placed at (x, y), so that distance heuristics such
+// as Euclidean can estimate the cost between nodes.
+//
+// Params:
+//
+//	id: unique node ID
+//	x, y: node coordinates
+//
+// Returns:
+//
+//	*Node: the new node
+//	error: if a node with the same ID already exists
+func (g *Graph) AddNodeAt(id, x, y int) (*Node, error) {
+	if _, exists := g.nodes[id]; exists {
+		return nil, fmt.Errorf("node %d already exists", id)
+	}
+
+	node := NewNode(x, y)
+	node.ID = id
+	g.nodes[id] = node
+	g.order = append(g.order, id)
+	return node, nil
+}
+
+// GetNodeByID returns the node with the given ID.
+//
+// Params:
+//
+//	id: node ID
+//
+// Returns:
+//
+//	*Node: node with the ID
+//	error: if no such node exists
+func (g *Graph) GetNodeByID(id int) (*Node, error) {
+	node, exists := g.nodes[id]
+	if !exists {
+		return nil, fmt.Errorf("node %d does not exist", id)
+	}
+	return node, nil
+}
+
+// Nodes returns all nodes in insertion order.
+//
+// Returns:
+//
+//	[]*Node: slice of all nodes
+func (g *Graph) Nodes() []*Node {
+	nodes := make([]*Node, len(g.order))
+	for i, id := range g.order {
+		nodes[i] = g.nodes[id]
+	}
+	return nodes
+}
+
+// NodeCount returns the number of nodes in the graph.
+func (g *Graph) NodeCount() int {
+	return len(g.nodes)
+}
+
+// EdgeCount returns the number of directed edges in the graph.
+func (g *Graph) EdgeCount() int {
+	return g.edgeCount
+}
+
+// AddEdge adds a directed edge from one node to another. If the edge
+// already exists, its weight is replaced.
+//
+// Params:
+//
+//	from, to: IDs of the source and target nodes
+//	weight: cost of traversing the edge (must be >= 0)
+//
+// Returns:
+//
+//	error: if either node does not exist or the weight is invalid
+func (g *Graph) AddEdge(from, to int, weight float64) error {
+	if math.IsNaN(weight) || math.IsInf(weight, 0) || weight < 0 {
+		return fmt.Errorf("edge weight must be >= 0, got %v", weight)
+	}
+	source, err := g.GetNodeByID(from)
+	if err != nil {
+		return err
+	}
+	target, err := g.GetNodeByID(to)
+	if err != nil {
+		return err
+	}
+
+	if !setEdgeWeight(g.out[from], to, weight) {
+		g.out[from] = append(g.out[from], graphEdge{target, weight})
+		g.in[to] = append(g.in[to], graphEdge{source, weight})
+		g.edgeCount++
+		return nil
+	}
+	setEdgeWeight(g.in[to], from, weight)
+	return nil
+}
+
+// AddUndirectedEdge adds edges in both directions with the same weight.
+//
+// Params:
+//
+//	a, b: IDs of the endpoints
+//	weight: cost of traversing the edge either way (must be >= 0)
+//
+// Returns:
+//
+//	error: if either node does not exist or the weight is invalid
+func (g *Graph) AddUndirectedEdge(a, b int, weight float64) error {
+	if err := g.AddEdge(a, b, weight); err != nil {
+		return err
+	}
+	return g.AddEdge(b, a, weight)
+}
+
+// RemoveEdge removes the directed edge from one node to another.
+//
+// Params:
+//
+//	from, to: IDs of the source and target nodes
+//
+// Returns:
+//
+//	error: if the edge does not exist
+func (g *Graph) RemoveEdge(from, to int) error {
+	out, removed := removeEdge(g.out[from], to)
+	if !removed {
+		return fmt.Errorf("edge %d -> %d does not exist", from, to)
+	}
+	g.out[from] = out
+	g.in[to], _ = removeEdge(g.in[to], from)
+	g.edgeCount--
+	return nil
+}
+
+// Weight returns the weight of the directed edge from one node to another.
+//
+// Params:
+//
+//	from, to: IDs of the source and target nodes
+//
+// Returns:
+//
+//	float64: edge weight
+//	bool: true if the edge exists
+func (g *Graph) Weight(from, to int) (float64, bool) {
+	for _, edge := range g.out[from] {
+		if edge.node.ID == to {
+			return edge.weight, true
+		}
+	}
+	return 0, false
+}
+
+// GetNeighbors returns the targets of a node's outgoing edges, excluding
+// obstacle nodes.
+//
+// Params:
+//
+//	node: the node to get neighbors for
+//
+// Returns:
+//
+//	[]*Node: slice of neighboring nodes
+func (g *Graph) GetNeighbors(node *Node) []*Node {
+	return walkableEndpoints(g.out[node.ID])
+}
+
+// GetPredecessors returns the sources of a node's incoming edges, excluding
+// obstacle nodes. Backward searches use it to walk edges in reverse.
+//
+// Params:
+//
+//	node: the node to get predecessors for
+//
+// Returns:
+//
+//	[]*Node: slice of predecessor nodes
+func (g *Graph) GetPredecessors(node *Node) []*Node {
+	return walkableEndpoints(g.in[node.ID])
+}
+
+// GetCost returns the cost of moving along the edge between two nodes: the
+// edge weight multiplied by the destination's Cost, or +Inf if there is no
+// such edge.
+//
+// Params:
+//
+//	from, to: source and destination nodes
+//
+// Returns:
+//
+//	float64: movement cost
+func (g *Graph) GetCost(from, to *Node) float64 {
+	weight, ok := g.Weight(from.ID, to.ID)
+	if !ok {
+		return math.Inf(1)
+	}
+	return weight * to.Cost
+}
+
+// Reset clears all algorithm state from all nodes in the graph.
+func (g *Graph) Reset() {
+	for _, node := range g.nodes {
+		node.Reset()
+	}
+}
+
+// Heuristic returns an admissible and consistent heuristic for the graph:
+// the Euclidean distance between node coordinates, scaled by the smallest
+// ratio of edge cost to edge length over all edges. The scale keeps the
+// estimate below the true cost even when weights are not distances, such
+// as travel times. The result reflects the edges at the time of the call.
+//
+// Returns:
+//
+//	HeuristicFunc: scaled Euclidean heuristic, or Zero if no edge has a
+//	positive length
+func (g *Graph) Heuristic() HeuristicFunc {
+	scale := math.Inf(1)
+	for _, id := range g.order {
+		from := g.nodes[id]
+		for _, edge := range g.out[id] {
+			length := Euclidean(from, edge.node)
+			if length > 0 {
+				scale = math.Min(scale, g.GetCost(from, edge.node)/length)
+			}
+		}
+	}
+
+	if math.IsInf(scale, 1) || scale == 0 {
+		return Zero
+	}
+	return func(current, goal *Node) float64 {
+		return scale * Euclidean(current, goal)
+	}
+}
+
+// String returns a string representation of the graph for debugging, one
+// line per node listing its outgoing edges.
+func (g *Graph) String() string {
+	result := fmt.Sprintf("Graph (%d nodes, %d edges):\n", len(g.nodes), g.edgeCount)
+
+	for _, id := range g.order {
+		result += fmt.Sprintf("%d:", id)
+		for _, edge := range g.out[id] {
+			result += fmt.Sprintf(" ->%d(%g)", edge.node.ID, edge.weight)
+		}
+		result += "\n"
+	}
+
+	return result
+}
+
+// setEdgeWeight updates the weight of the edge to the node with the given
+// ID in an adjacency list, reporting whether it was found.
+func setEdgeWeight(edges []graphEdge, id int, weight float64) bool {
+	for i := range edges {
+		if edges[i].node.ID == id {
+			edges[i].weight = weight
+			return true
+		}
+	}
+	return false
+}
+
+// removeEdge deletes the edge to the node with the given ID from an
+// adjacency list, preserving the order of the remaining edges.
+func removeEdge(edges []graphEdge, id int) ([]graphEdge, bool) {
+	for i, edge := range edges {
+		if edge.node.ID == id {
+			return append(edges[:i], edges[i+1:]...), true
+		}
+	}
+	return edges, false
+}
+
+// walkableEndpoints returns the non-obstacle endpoints of an adjacency list.
+func walkableEndpoints(edges []graphEdge) []*Node {
+	nodes := make([]*Node, 0, len(edges))
+	for _, edge := range edges {
+		if !edge.node.IsObstacle {
+			nodes = append(nodes, edge.node)
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,436 @@
+package algo
+
+import (
+	"math"
+	"testing"
+)
+
+// newRoadGraph builds a small directed road network. Every node sits at the
+// origin, so nodes are told apart by ID alone. The cheapest route from 1 to
+// 6 is 1-3-2-4-5-6 with cost 12, followed by 1-2-4-5-6 and 1-3-5-6 with
+// cost 13; 3 -> 2 is one-way and nothing leaves 6.
+func newRoadGraph(t *testing.T) *Graph {
+	t.Helper()
+
+	graph := NewGraph()
+	for id := 1; id <= 6; id++ {
+		if _, err := graph.AddNode(id); err != nil {
+			t.Fatalf("AddNode(%d) returned error: %v", id, err)
+		}
+	}
+
+	edges := []struct {
+		from, to int
+		weight   float64
+	}{
+		{1, 2, 4}, {2, 1, 4},
+		{1, 3, 2}, {3, 2, 1},
+		{2, 4, 5}, {3, 4, 8}, {3, 5, 10},
+		{4, 5, 3}, {4, 6, 6}, {5, 6, 1},
+	}
+	for _, e := range edges {
+		if err := graph.AddEdge(e.from, e.to, e.weight); err != nil {
+			t.Fatalf("AddEdge(%d, %d) returned error: %v", e.from, e.to, err)
+		}
+	}
+	return graph
+}
+
+// graphPathIDs returns the node IDs along a path
+func graphPathIDs(path []*Node) []int {
+	ids := make([]int, len(path))
+	for i, node := range path {
+		ids[i] = node.ID
+	}
+	return ids
+}
+
+// assertGraphPath checks that a path follows existing edges from start to goal
+func assertGraphPath(t *testing.T, graph *Graph, path []*Node, start, goal int) {
+	t.Helper()
+
+	if len(path) == 0 || path[0].ID != start || path[len(path)-1].ID != goal {
+		t.Fatalf("Path %v does not run from %d to %d", graphPathIDs(path), start, goal)
+	}
+	for i := 1; i < len(path); i++ {
+		if _, ok := graph.Weight(path[i-1].ID, path[i].ID); !ok {
+			t.Fatalf("Path %v uses missing edge %d -> %d", graphPathIDs(path), path[i-1].ID, path[i].ID)
+		}
+	}
+}
+
+func TestGraphConstruction(t *testing.T) {
+	graph := NewGraph()
+
+	node, err := graph.AddNodeAt(42, 3, 4)
+	if err != nil {
+		t.Fatalf("AddNodeAt returned error: %v", err)
+	}
+	if node.ID != 42 || node.X != 3 || node.Y != 4 || node.Cost != 1.0 {
+		t.Errorf("Unexpected node %+v", node)
+	}
+	if _, err := graph.AddNode(42); err == nil {
+		t.Error("Expected error for duplicate node ID")
+	}
+	if found, err := graph.GetNodeByID(42); err != nil || found != node {
+		t.Error("GetNodeByID should return the added node")
+	}
+	if _, err := graph.GetNodeByID(7); err == nil {
+		t.Error("Expected error for unknown node ID")
+	}
+
+	graph.AddNode(-5)
+	if err := graph.AddEdge(42, 7, 1); err == nil {
+		t.Error("Expected error for edge to unknown node")
+	}
+	if err := graph.AddEdge(42, -5, -1); err == nil {
+		t.Error("Expected error for negative weight")
+	}
+	if err := graph.AddEdge(42, -5, math.NaN()); err == nil {
+		t.Error("Expected error for NaN weight")
+	}
+
+	graph.AddEdge(42, -5, 2)
+	graph.AddEdge(42, -5, 3)
+	if w, ok := graph.Weight(42, -5); !ok || w != 3 {
+		t.Errorf("AddEdge should replace the weight, got %.1f", w)
+	}
+	if _, ok := graph.Weight(-5, 42); ok {
+		t.Error("Edges should be directed")
+	}
+	if graph.NodeCount() != 2 || graph.EdgeCount() != 1 {
+		t.Errorf("Expected 2 nodes and 1 edge, got %d and %d", graph.NodeCount(), graph.EdgeCount())
+	}
+
+	graph.AddUndirectedEdge(42, -5, 1)
+	if graph.EdgeCount() != 2 {
+		t.Errorf("Expected 2 edges, got %d", graph.EdgeCount())
+	}
+
+	if err := graph.RemoveEdge(42, -5); err != nil {
+		t.Errorf("RemoveEdge returned error: %v", err)
+	}
+	if err := graph.RemoveEdge(42, -5); err == nil {
+		t.Error("Expected error removing a missing edge")
+	}
+	if graph.EdgeCount() != 1 {
+		t.Errorf("Expected 1 edge, got %d", graph.EdgeCount())
+	}
+
+	nodes := graph.Nodes()
+	if len(nodes) != 2 || nodes[0].ID != 42 || nodes[1].ID != -5 {
+		t.Errorf("Nodes() should list nodes in insertion order, got %v", graphPathIDs(nodes))
+	}
+}
+
+func TestGraphNeighbors(t *testing.T) {
+	graph := newRoadGraph(t)
+	n1, _ := graph.GetNodeByID(1)
+	n2, _ := graph.GetNodeByID(2)
+	n3, _ := graph.GetNodeByID(3)
+	n6, _ := graph.GetNodeByID(6)
+
+	if ids := graphPathIDs(graph.GetNeighbors(n1)); len(ids) != 2 || ids[0] != 2 || ids[1] != 3 {
+		t.Errorf("Expected neighbors [2 3] of node 1, got %v", ids)
+	}
+	if ids := graphPathIDs(graph.GetPredecessors(n2)); len(ids) != 2 || ids[0] != 1 || ids[1] != 3 {
+		t.Errorf("Expected predecessors [1 3] of node 2, got %v", ids)
+	}
+	if len(graph.GetNeighbors(n6)) != 0 {
+		t.Error("Node 6 has no outgoing edges")
+	}
+
+	// GetCost follows the edge direction and scales by the target's cost
+	if cost := graph.GetCost(n3, n2); cost != 1 {
+		t.Errorf("Expected cost 1 for 3 -> 2, got %.1f", cost)
+	}
+	if cost := graph.GetCost(n2, n3); !math.IsInf(cost, 1) {
+		t.Errorf("Expected +Inf for missing edge 2 -> 3, got %.1f", cost)
+	}
+	n2.Cost = 2
+	if cost := graph.GetCost(n3, n2); cost != 2 {
+		t.Errorf("Expected weighted cost 2, got %.1f", cost)
+	}
+
+	n3.IsObstacle = true
+	if ids := graphPathIDs(graph.GetNeighbors(n1)); len(ids) != 1 || ids[0] != 2 {
+		t.Errorf("Obstacle nodes should not be neighbors, got %v", ids)
+	}
+}
+
+// TestGraph_Pathfinders runs the general-purpose pathfinders on a graph
+// whose nodes share coordinates
+func TestGraph_Pathfinders(t *testing.T) {
+	pathfinders := map[string]Pathfinder{
+		"AStar":              NewAStar(),
+		"Dijkstra":           NewDijkstra(),
+		"BidirectionalAStar": NewBidirectionalAStar(),
+		"IDAStar":            NewIDAStar(),
+		"ARAStar":            NewARAStar(),
+		"KShortest":          NewKShortest(),
+		"DStarLite":          NewDStarLite(),
+		"LPAStar":            NewLPAStar(),
+	}
+
+	for name, pathfinder := range pathfinders {
+		t.Run(name, func(t *testing.T) {
+			graph := newRoadGraph(t)
+			start, _ := graph.GetNodeByID(1)
+			goal, _ := graph.GetNodeByID(6)
+
+			pathfinder.SetGrid(graph)
+			pathfinder.SetHeuristic(Zero)
+			path, err := pathfinder.FindPath(start, goal)
+			if err != nil {
+				t.Fatalf("FindPath returned error: %v", err)
+			}
+			assertGraphPath(t, graph, path, 1, 6)
+			if cost := PathCost(graph, path); cost != 12 {
+				t.Errorf("Expected cost 12, got %.1f along %v", cost, graphPathIDs(path))
+			}
+
+			// Nothing leaves node 6
+			if _, err := pathfinder.FindPath(goal, start); err == nil {
+				t.Error("Expected error searching against one-way edges")
+			}
+		})
+	}
+
+	t.Run("GreedyBestFirst", func(t *testing.T) {
+		graph := newRoadGraph(t)
+		start, _ := graph.GetNodeByID(1)
+		goal, _ := graph.GetNodeByID(6)
+
+		greedy := NewGreedyBestFirst()
+		greedy.SetGrid(graph)
+		greedy.SetHeuristic(Zero)
+		path, err := greedy.FindPath(start, goal)
+		if err != nil {
+			t.Fatalf("FindPath returned error: %v", err)
+		}
+		assertGraphPath(t, graph, path, 1, 6)
+	})
+
+	t.Run("BFS", func(t *testing.T) {
+		graph := NewGraph()
+		for id := 0; id < 5; id++ {
+			graph.AddNode(id)
+		}
+		for _, e := range [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {0, 3}} {
+			graph.AddEdge(e[0], e[1], 1)
+		}
+		start, _ := graph.GetNodeByID(0)
+		goal, _ := graph.GetNodeByID(4)
+
+		bfs := NewBFS()
+		bfs.SetGrid(graph)
+		path, err := bfs.FindPath(start, goal)
+		if err != nil {
+			t.Fatalf("FindPath returned error: %v", err)
+		}
+		assertGraphPath(t, graph, path, 0, 4)
+		if len(path) != 3 {
+			t.Errorf("Expected 2 edges, got path %v", graphPathIDs(path))
+		}
+	})
+}
+
+// TestGraph_KShortest checks the alternatives on the road graph in cost order
+func TestGraph_KShortest(t *testing.T) {
+	graph := newRoadGraph(t)
+	start, _ := graph.GetNodeByID(1)
+	goal, _ := graph.GetNodeByID(6)
+
+	ks := NewKShortest()
+	ks.SetGrid(graph)
+	ks.SetHeuristic(Zero)
+	paths, err := ks.KShortestPaths(start, goal, 3)
+	if err != nil {
+		t.Fatalf("KShortestPaths returned error: %v", err)
+	}
+
+	want := []float64{12, 13, 13}
+	if len(paths) != len(want) {
+		t.Fatalf("Expected %d paths, got %d", len(want), len(paths))
+	}
+	for i, p := range paths {
+		assertGraphPath(t, graph, p.Path, 1, 6)
+		if p.Cost != want[i] {
+			t.Errorf("Path %d: expected cost %.0f, got %.0f", i, want[i], p.Cost)
+		}
+	}
+}
+
+// TestGraph_KShortest_EqualLengths checks that alternatives with the same
+// number of nodes are kept apart on a graph without coordinates
+func TestGraph_KShortest_EqualLengths(t *testing.T) {
+	graph := NewGraph()
+	for id := 1; id <= 4; id++ {
+		graph.AddNode(id)
+	}
+	graph.AddEdge(1, 2, 1)
+	graph.AddEdge(2, 4, 1)
+	graph.AddEdge(1, 3, 1)
+	graph.AddEdge(3, 4, 1.5)
+	start, _ := graph.GetNodeByID(1)
+	goal, _ := graph.GetNodeByID(4)
+
+	ks := NewKShortest()
+	ks.SetGrid(graph)
+	ks.SetHeuristic(Zero)
+	paths, err := ks.KShortestPaths(start, goal, 3)
+	if err != nil {
+		t.Fatalf("KShortestPaths returned error: %v", err)
+	}
+
+	want := []float64{2, 2.5}
+	if len(paths) != len(want) {
+		t.Fatalf("Expected %d paths, got %d", len(want), len(paths))
+	}
+	for i, p := range paths {
+		assertGraphPath(t, graph, p.Path, 1, 4)
+		if p.Cost != want[i] {
+			t.Errorf("Path %d: expected cost %.1f, got %.1f", i, want[i], p.Cost)
+		}
+	}
+}
+
+// TestGraph_IncrementalPlanners checks that D* Lite and LPA* repair their
+// plans along directed edges after a node's cost changes
+func TestGraph_IncrementalPlanners(t *testing.T) {
+	graph := newRoadGraph(t)
+	start, _ := graph.GetNodeByID(1)
+	goal, _ := graph.GetNodeByID(6)
+	via, _ := graph.GetNodeByID(2)
+
+	dstar := NewDStarLite()
+	dstar.SetGrid(graph)
+	dstar.SetHeuristic(Zero)
+	lpa := NewLPAStar()
+	lpa.SetGrid(graph)
+	lpa.SetHeuristic(Zero)
+
+	if _, err := dstar.FindPath(start, goal); err != nil {
+		t.Fatalf("D* Lite FindPath returned error: %v", err)
+	}
+	if _, err := lpa.FindPath(start, goal); err != nil {
+		t.Fatalf("LPA* FindPath returned error: %v", err)
+	}
+
+	// Entering node 2 becomes expensive, so 1-3-5-6 (cost 13) is cheapest
+	via.Cost = 10
+	if err := dstar.NotifyChanged(via); err != nil {
+		t.Fatalf("NotifyChanged returned error: %v", err)
+	}
+	lpa.NotifyChanged(via)
+
+	dstarPath, err := dstar.Replan()
+	if err != nil {
+		t.Fatalf("D* Lite Replan returned error: %v", err)
+	}
+	lpaPath, err := lpa.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("LPA* FindPath returned error: %v", err)
+	}
+
+	for name, path := range map[string][]*Node{"DStarLite": dstarPath, "LPAStar": lpaPath} {
+		assertGraphPath(t, graph, path, 1, 6)
+		if cost := PathCost(graph, path); cost != 13 {
+			t.Errorf("%s: expected cost 13 after the change, got %.1f along %v",
+				name, cost, graphPathIDs(path))
+		}
+	}
+}
+
+// TestGraph_Heuristic checks that the scaled heuristic never overestimates
+// travel times and still finds the cheapest route
+func TestGraph_Heuristic(t *testing.T) {
+	graph := NewGraph()
+	positions := [][2]int{{0, 0}, {10, 0}, {20, 0}, {10, 10}, {20, 10}}
+	for id, pos := range positions {
+		graph.AddNodeAt(id, pos[0], pos[1])
+	}
+
+	// Weights are travel times: a fast highway through nodes 3 and 4 and
+	// slow streets through node 1
+	connect := func(a, b int, speed float64) {
+		na, _ := graph.GetNodeByID(a)
+		nb, _ := graph.GetNodeByID(b)
+		graph.AddUndirectedEdge(a, b, Euclidean(na, nb)/speed)
+	}
+	connect(0, 1, 1)
+	connect(1, 2, 1)
+	connect(0, 3, 4)
+	connect(3, 4, 4)
+	connect(4, 2, 4)
+
+	if h := NewGraph().Heuristic(); h(NewNode(0, 0), NewNode(5, 5)) != 0 {
+		t.Error("Heuristic of a graph without edges should be zero")
+	}
+
+	heuristic := graph.Heuristic()
+	astar := NewAStar()
+	astar.SetGrid(graph)
+	astar.SetHeuristic(Zero)
+
+	for _, from := range graph.Nodes() {
+		for _, to := range graph.Nodes() {
+			result, err := astar.Search(from, to)
+			if err != nil {
+				t.Fatalf("Search(%d, %d) returned error: %v", from.ID, to.ID, err)
+			}
+			if h := heuristic(from, to); h > result.Cost+1e-9 {
+				t.Errorf("Heuristic from %d to %d is %.3f, above the true cost %.3f", from.ID, to.ID, h, result.Cost)
+			}
+		}
+	}
+
+	start, _ := graph.GetNodeByID(0)
+	goal, _ := graph.GetNodeByID(2)
+	astar.SetHeuristic(heuristic)
+	result, err := astar.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	// The highway takes 3 * 10/4 = 7.5 time units against 20 on the
+	// direct streets
+	if ids := graphPathIDs(result.Path); len(ids) != 4 || ids[1] != 3 {
+		t.Errorf("Expected the route over the highway, got %v", ids)
+	}
+}
+
+func TestGraph_WaypointRouter(t *testing.T) {
+	graph := newRoadGraph(t)
+	start, _ := graph.GetNodeByID(1)
+	end, _ := graph.GetNodeByID(6)
+	n2, _ := graph.GetNodeByID(2)
+	n3, _ := graph.GetNodeByID(3)
+
+	router := NewWaypointRouter()
+	astar := NewAStar()
+	astar.SetHeuristic(Zero)
+	router.SetPathfinder(astar)
+	router.SetGrid(graph)
+
+	route, err := router.Route(start, []*Node{n2, n3}, end)
+	if err != nil {
+		t.Fatalf("Route returned error: %v", err)
+	}
+	// 3 must come before 2 because 2 -> 3 does not exist
+	if route.Order[0] != 1 || route.Cost != 12 {
+		t.Errorf("Expected order [1 0] with cost 12, got %v with cost %.1f", route.Order, route.Cost)
+	}
+	assertGraphPath(t, graph, route.Path, 1, 6)
+}
+
+func TestGraphString(t *testing.T) {
+	graph := NewGraph()
+	graph.AddNode(1)
+	graph.AddNode(2)
+	graph.AddEdge(1, 2, 2.5)
+
+	want := "Graph (2 nodes, 1 edges):\n1: ->2(2.5)\n2:\n"
+	if got := graph.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,161 @@
+package algo
+
+import (
+	"fmt"
+	"math"
+)
+
+// GreedyBestFirst implements greedy best-first search.
+// It orders the open set purely by the heuristic estimate H, ignoring the
+// cost already travelled. This makes it very fast at finding "a" path when
+// the heuristic points roughly the right way, but the path is NOT
+// guaranteed to be optimal. Use it for agents that need a quick answer
+// rather than the best one.
+type GreedyBestFirst struct {
+	// grid is the search space we're pathfinding within
+	grid SearchSpace
+
+	// heuristic function estimates cost from current node to goal
+	heuristic HeuristicFunc
+
+	// openSet contains nodes to be evaluated, ordered by h-cost
+	openSet *PriorityQueue
+
+	// closedSet contains nodes already evaluated (coordinates -> bool)
+	closedSet map[nodeKey]bool
+}
+
+// NewGreedyBestFirst creates a new greedy best-first pathfinder instance.
+// By default, it uses the Manhattan heuristic.
+//
+// Returns:
+//
+//	*GreedyBestFirst: new greedy best-first pathfinder instance
+func NewGreedyBestFirst() *GreedyBestFirst {
+	return &GreedyBestFirst{
+		heuristic: Manhattan,
+		openSet:   NewPriorityQueue(),
+		closedSet: make(map[nodeKey]bool),
+	}
+}
+
+// SetGrid configures the grid/search space for the pathfinder.
+// This must be called before FindPath.
+//
+// Params:
+//
+//	grid: grid to search within
+func (gb *GreedyBestFirst) SetGrid(grid SearchSpace) {
+	gb.grid = grid
+}
+
+// SetHeuristic configures the heuristic function used to order the open set.
+// Since greedy search follows the heuristic alone, admissibility is not
+// required, but a more informed heuristic gives straighter paths.
+//
+// Params:
+//
+//	heuristic: heuristic function to use
+func (gb *GreedyBestFirst) SetHeuristic(heuristic HeuristicFunc) {
+	gb.heuristic = heuristic
+}
+
+// FindPath finds a path from start to goal using greedy best-first search.
+// The path is not guaranteed to be optimal; use Search to inspect this.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	[]*Node: path from start to goal (including both endpoints)
+//	error: if no path exists or invalid input
+func (gb *GreedyBestFirst) FindPath(start, goal *Node) ([]*Node, error) {
+	result, err := gb.Search(start, goal)
+	if err != nil {
+		return nil, err
+	}
+	return result.Path, nil
+}
+
+// Search runs greedy best-first search and returns a detailed result.
+// The result's Optimal field is always false and Bound is +Inf, since
+// greedy search does not account for the cost travelled so far.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	*SearchResult: path, its cost and search statistics
+//	error: if no path exists or invalid input
+func (gb *GreedyBestFirst) Search(start, goal *Node) (*SearchResult, error) {
+	if err := validateEndpoints(gb.grid, start, goal); err != nil {
+		return nil, err
+	}
+
+	if gb.heuristic == nil {
+		return nil, fmt.Errorf("heuristic must be set before calling FindPath")
+	}
+
+	if start.Equals(goal) {
+		return &SearchResult{Path: []*Node{start}, Bound: math.Inf(1)}, nil
+	}
+
+	gb.reset()
+
+	// F holds only the heuristic so the queue orders by h-cost
+	start.G = 0
+	start.H = gb.heuristic(start, goal)
+	start.F = start.H
+	start.Parent = nil
+	gb.openSet.PushNode(start)
+
+	expanded := 0
+	for !gb.openSet.IsEmpty() {
+		current := gb.openSet.PopNode()
+
+		if current.Equals(goal) {
+			path := reconstructPath(current)
+			return &SearchResult{
+				Path:          path,
+				Cost:          current.G,
+				NodesExpanded: expanded,
+				Bound:         math.Inf(1),
+			}, nil
+		}
+
+		gb.closedSet[keyOf(current)] = true
+		expanded++
+
+		for _, neighbor := range gb.grid.GetNeighbors(current) {
+			// Greedy search never revisits a node once it has been discovered
+			if neighbor.IsObstacle || gb.closedSet[keyOf(neighbor)] ||
+				gb.openSet.ContainsNode(neighbor) {
+				continue
+			}
+
+			neighbor.G = current.G + gb.grid.GetCost(current, neighbor)
+			neighbor.H = gb.heuristic(neighbor, goal)
+			neighbor.F = neighbor.H
+			neighbor.Parent = current
+			gb.openSet.PushNode(neighbor)
+		}
+	}
+
+	return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+		start.X, start.Y, goal.X, goal.Y)
+}
+
+// reset clears the algorithm state for a fresh pathfinding operation.
+func (gb *GreedyBestFirst) reset() {
+	gb.openSet.Clear()
+	gb.closedSet = make(map[nodeKey]bool)
+	if gb.grid != nil {
+		gb.grid.Reset()
+	}
+}
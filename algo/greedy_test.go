@@ -0,0 +1,143 @@
+package algo
+
+import (
+	"math"
+	"testing"
+)
+
+// TestGreedyBestFirst_ImplementsPathfinder verifies interface compliance
+func TestGreedyBestFirst_ImplementsPathfinder(t *testing.T) {
+	var _ Pathfinder = NewGreedyBestFirst()
+}
+
+// TestGreedyBestFirst_NewGreedyBestFirst tests the constructor defaults
+func TestGreedyBestFirst_NewGreedyBestFirst(t *testing.T) {
+	gb := NewGreedyBestFirst()
+
+	if gb.heuristic == nil {
+		t.Error("heuristic should be set to default (Manhattan)")
+	}
+
+	if gb.openSet == nil || gb.closedSet == nil {
+		t.Error("open and closed sets should be initialized")
+	}
+}
+
+// TestGreedyBestFirst_Search_NotOptimal tests the result metadata
+func TestGreedyBestFirst_Search_NotOptimal(t *testing.T) {
+	grid, _ := NewGrid(10, 10, FourWay)
+	gb := NewGreedyBestFirst()
+	gb.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(9, 9)
+
+	result, err := gb.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search() returned error: %v", err)
+	}
+
+	if result.Optimal {
+		t.Error("Greedy best-first results must not be reported as optimal")
+	}
+
+	if !math.IsInf(result.Bound, 1) {
+		t.Errorf("Greedy best-first bound should be +Inf, got %f", result.Bound)
+	}
+
+	if !result.Path[0].Equals(start) || !result.Path[len(result.Path)-1].Equals(goal) {
+		t.Error("Path should run from start to goal")
+	}
+
+	if result.Cost != PathCost(grid, result.Path) {
+		t.Errorf("Result cost %.2f does not match path cost %.2f", result.Cost, PathCost(grid, result.Path))
+	}
+
+	// On an open grid the heuristic leads straight to the goal
+	if result.NodesExpanded != len(result.Path)-1 {
+		t.Errorf("Expected %d expansions on open grid, got %d", len(result.Path)-1, result.NodesExpanded)
+	}
+}
+
+// TestGreedyBestFirst_FindPath_Suboptimal tests that greedy search follows
+// the heuristic straight through expensive terrain that A* avoids
+func TestGreedyBestFirst_FindPath_Suboptimal(t *testing.T) {
+	grid, _ := NewGrid(5, 3, FourWay)
+	for x := 1; x < 5; x++ {
+		node, _ := grid.GetNode(x, 1)
+		node.Cost = 10.0
+	}
+
+	start, _ := grid.GetNode(0, 1)
+	goal, _ := grid.GetNode(4, 1)
+
+	gb := NewGreedyBestFirst()
+	gb.SetGrid(grid)
+	result, err := gb.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search() returned error: %v", err)
+	}
+
+	a := NewAStar()
+	a.SetGrid(grid)
+	optimalPath, err := a.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("AStar FindPath() returned error: %v", err)
+	}
+	optimalCost := PathCost(grid, optimalPath)
+
+	// Straight through the weighted row: 3 * 10 + 10 for entering the goal
+	if result.Cost != 40.0 {
+		t.Errorf("Expected greedy cost 40.0, got %.2f", result.Cost)
+	}
+
+	if result.Cost <= optimalCost {
+		t.Errorf("Greedy cost %.2f should exceed optimal cost %.2f here", result.Cost, optimalCost)
+	}
+}
+
+// TestGreedyBestFirst_FindPath_NoPath tests when no path exists
+func TestGreedyBestFirst_FindPath_NoPath(t *testing.T) {
+	grid, _ := NewGrid(5, 5, EightWay)
+	for y := 0; y < 5; y++ {
+		grid.SetObstacle(2, y)
+	}
+
+	gb := NewGreedyBestFirst()
+	gb.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 2)
+	goal, _ := grid.GetNode(4, 2)
+
+	if path, err := gb.FindPath(start, goal); err == nil || path != nil {
+		t.Error("FindPath() should return nil path and error when no path exists")
+	}
+}
+
+// TestGreedyBestFirst_FindPath_InputValidation tests error handling for invalid inputs
+func TestGreedyBestFirst_FindPath_InputValidation(t *testing.T) {
+	gb := NewGreedyBestFirst()
+	grid, _ := NewGrid(5, 5, FourWay)
+	node, _ := grid.GetNode(0, 0)
+	other, _ := grid.GetNode(3, 3)
+
+	if _, err := gb.FindPath(nil, node); err == nil {
+		t.Error("FindPath() should return error for nil start node")
+	}
+
+	if _, err := gb.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when grid is not set")
+	}
+
+	gb.SetGrid(grid)
+	gb.SetHeuristic(nil)
+	if _, err := gb.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when heuristic is nil")
+	}
+
+	gb.SetHeuristic(Euclidean)
+	path, err := gb.FindPath(node, node)
+	if err != nil || len(path) != 1 {
+		t.Errorf("Expected single-node path for same start/goal, got %v (err=%v)", path, err)
+	}
+}
@@ -3,6 +3,7 @@ package algo
 import (
 	"errors"
 	"fmt"
+	"math"
 )
 
 // MovementType defines the type of movement allowed in the grid
@@ -15,6 +16,25 @@ const (
 	EightWay
 )
 
+// DiagonalPolicy defines when EightWay movement may step diagonally past
+// the two cells orthogonally adjacent to both ends of the step
+type DiagonalPolicy int
+
+const (
+	// DiagonalAlways allows every diagonal step, even between two
+	// orthogonal obstacles (the default)
+	DiagonalAlways DiagonalPolicy = iota
+	// DiagonalNever forbids diagonal steps, so EightWay behaves like FourWay
+	DiagonalNever
+	// DiagonalIfNoObstacles allows a diagonal step only if both orthogonal
+	// cells are walkable, so paths never cut corners
+	DiagonalIfNoObstacles
+	// DiagonalIfAtMostOneObstacle allows a diagonal step unless both
+	// orthogonal cells are obstacles, so paths cut corners but never
+	// squeeze between two obstacles
+	DiagonalIfAtMostOneObstacle
+)
+
 // Grid represents a 2D pathfinding grid containing nodes.
 // It provides methods for accessing nodes, checking boundaries,
 // and getting neighbors for pathfinding algorithms.
@@ -27,6 +47,9 @@ type Grid struct {
 
 	// Movement configuration
 	MovementType MovementType
+
+	// DiagonalPolicy restricts diagonal steps under EightWay movement
+	DiagonalPolicy DiagonalPolicy
 }
 
 // NewGrid creates a new grid with the specified dimensions.
@@ -163,17 +186,20 @@ func (g *Grid) GetNeighbors(node *Node) []*Node {
 		newX := node.X + dir[0]
 		newY := node.Y + dir[1]
 
-		if g.IsValidPosition(newX, newY) && !g.nodes[newY][newX].IsObstacle {
-			neighbors = append(neighbors, g.nodes[newY][newX])
+		if !g.IsValidPosition(newX, newY) || g.nodes[newY][newX].IsObstacle {
+			continue
+		}
+		if dir[0] != 0 && dir[1] != 0 && !g.canStepDiagonally(node.X, node.Y, dir[0], dir[1]) {
+			continue
 		}
+		neighbors = append(neighbors, g.nodes[newY][newX])
 	}
 
 	return neighbors
 }
 
 // GetCost returns the movement cost from one node to another.
-// For now, this returns the base movement cost, but can be extended
-// for weighted grids or terrain-based costs.
+// A diagonal step forbidden by the DiagonalPolicy costs +Inf.
 //
 // Params:
 //
@@ -190,6 +216,10 @@ func (g *Grid) GetCost(from, to *Node) float64 {
 	// Apply terrain cost multiplier
 	baseCost := 1.0
 	if dx != 0 && dy != 0 {
+		if math.Abs(dx) == 1 && math.Abs(dy) == 1 &&
+			!g.canStepDiagonally(from.X, from.Y, int(dx), int(dy)) {
+			return math.Inf(1)
+		}
 		// Diagonal movement costs sqrt(2) ≈ 1.414
 		baseCost = 1.414
 	}
@@ -197,6 +227,81 @@ func (g *Grid) GetCost(from, to *Node) float64 {
 	return baseCost * to.Cost
 }
 
+// HasUniformCost reports whether every node in the grid has a movement
+// cost multiplier of exactly 1.0. Obstacles are ignored since they are
+// never entered. Algorithms that assume unit costs (BFS, JPS) use this
+// to reject weighted grids up front.
+//
+// Returns:
+//
+//	bool: true if all walkable nodes have Cost == 1.0
+func (g *Grid) HasUniformCost() bool {
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			node := g.nodes[y][x]
+			if !node.IsObstacle && node.Cost != 1.0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// LineOfSight reports whether a straight line between the centers of two
+// cells crosses only walkable cells. Every cell the segment passes through
+// is checked (a "supercover" traversal). Where the line passes exactly
+// through a cell corner it steps diagonally, subject to the DiagonalPolicy
+// on the two cells beside the corner: DiagonalAlways passes between two
+// obstacles, DiagonalIfAtMostOneObstacle needs one of them walkable and
+// DiagonalIfNoObstacles needs both. DiagonalNever only forbids diagonal
+// steps between neighbors, so lines through corners follow the
+// DiagonalIfNoObstacles rule.
+//
+// Params:
+//
+//	x0, y0: coordinates of the first cell
+//	x1, y1: coordinates of the second cell
+//
+// Returns:
+//
+//	bool: true if neither endpoint nor any crossed cell is blocked
+func (g *Grid) LineOfSight(x0, y0, x1, y1 int) bool {
+	dx, dy := abs(x1-x0), abs(y1-y0)
+	stepX, stepY := sign(x1-x0), sign(y1-y0)
+
+	// err tracks which cell boundary the segment crosses next (scaled by 2)
+	err := dx - dy
+	dx *= 2
+	dy *= 2
+
+	x, y := x0, y0
+	for {
+		if g.IsObstacle(x, y) {
+			return false
+		}
+		if x == x1 && y == y1 {
+			return true
+		}
+
+		switch {
+		case err > 0:
+			x += stepX
+			err -= dy
+		case err < 0:
+			y += stepY
+			err += dx
+		default:
+			// Exact corner crossing: move diagonally if the policy allows
+			if !g.canCrossCorner(x, y, stepX, stepY) {
+				return false
+			}
+			x += stepX
+			y += stepY
+			err += dx - dy
+		}
+	}
+}
+
 // Reset clears all A* algorithm state from all nodes in the grid.
 // This allows reusing the grid for multiple pathfinding operations.
 func (g *Grid) Reset() {
@@ -254,6 +359,30 @@ func (g *Grid) String() string {
 	return result
 }
 
+// canStepDiagonally reports whether the DiagonalPolicy allows the diagonal
+// step from (x, y) in direction (dx, dy), judging by the two cells beside it.
+func (g *Grid) canStepDiagonally(x, y, dx, dy int) bool {
+	switch g.DiagonalPolicy {
+	case DiagonalNever:
+		return false
+	case DiagonalIfNoObstacles:
+		return !g.IsObstacle(x+dx, y) && !g.IsObstacle(x, y+dy)
+	case DiagonalIfAtMostOneObstacle:
+		return !g.IsObstacle(x+dx, y) || !g.IsObstacle(x, y+dy)
+	default:
+		return true
+	}
+}
+
+// canCrossCorner reports whether a straight line may pass through the
+// corner between (x, y) and (x+dx, y+dy).
+func (g *Grid) canCrossCorner(x, y, dx, dy int) bool {
+	if g.DiagonalPolicy == DiagonalNever {
+		return !g.IsObstacle(x+dx, y) && !g.IsObstacle(x, y+dy)
+	}
+	return g.canStepDiagonally(x, y, dx, dy)
+}
+
 // movementTypeString returns a string representation of the movement type
 func (g *Grid) movementTypeString() string {
 	switch g.MovementType {
@@ -265,3 +394,39 @@ func (g *Grid) movementTypeString() string {
 		return "unknown"
 	}
 }
+
+// diagonalPolicyString returns a string representation of the diagonal policy
+func (g *Grid) diagonalPolicyString() string {
+	switch g.DiagonalPolicy {
+	case DiagonalAlways:
+		return "always"
+	case DiagonalNever:
+		return "never"
+	case DiagonalIfNoObstacles:
+		return "if no obstacles"
+	case DiagonalIfAtMostOneObstacle:
+		return "if at most one obstacle"
+	default:
+		return "unknown"
+	}
+}
+
+// sign returns -1, 0 or 1 according to the sign of v.
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// abs returns the absolute value of v.
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
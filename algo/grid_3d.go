@@ -0,0 +1,317 @@
+package algo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Connectivity3D defines which neighbors a voxel is connected to
+type Connectivity3D int
+
+const (
+	// Connect6 allows movement across faces: ±x, ±y, ±z
+	Connect6 Connectivity3D = iota
+	// Connect18 allows movement across faces and edges
+	Connect18
+	// Connect26 allows movement across faces, edges and corners
+	Connect26
+)
+
+// Movement costs by the number of axes a step changes: 1, √2 and √3,
+// rounded like the diagonal cost of Grid.
+const (
+	straightCost3D     = 1.0
+	edgeDiagonalCost   = 1.414
+	cornerDiagonalCost = 1.732
+)
+
+// Grid3D represents a 3D voxel grid for volumes such as airspace or
+// multi-floor buildings.
+//
+// Nodes carry their layer in Node.Z, and Grid3D implements SearchSpace so
+// AStar and the other general pathfinders search it unchanged. It does not
+// implement GridInterface: tools built on GridInterface address cells by
+// (x, y) alone and would confuse voxels on different layers. Each voxel's
+// Cost multiplies the cost of entering it.
+type Grid3D struct {
+	// Grid dimensions
+	Width, Height, Depth int
+
+	// 3D array of nodes stored as nodes[z][y][x]
+	nodes [][][]*Node
+
+	// Connectivity is the neighborhood used by GetNeighbors
+	Connectivity Connectivity3D
+}
+
+// NewGrid3D creates a new 3D grid with the specified dimensions.
+// All nodes are initialized as empty (non-obstacle) nodes.
+//
+// Params:
+//
+//	width, height, depth: grid dimensions along x, y and z (must be > 0)
+//	connectivity: neighborhood (Connect6, Connect18 or Connect26)
+//
+// Returns:
+//
+//	*Grid3D: new 3D grid instance
+//	error: if dimensions are invalid
+func NewGrid3D(width, height, depth int, connectivity Connectivity3D) (*Grid3D, error) {
+	if width <= 0 || height <= 0 || depth <= 0 {
+		return nil, errors.New("grid dimensions must be positive")
+	}
+
+	grid := &Grid3D{
+		Width:        width,
+		Height:       height,
+		Depth:        depth,
+		nodes:        make([][][]*Node, depth),
+		Connectivity: connectivity,
+	}
+
+	for z := 0; z < depth; z++ {
+		grid.nodes[z] = make([][]*Node, height)
+		for y := 0; y < height; y++ {
+			grid.nodes[z][y] = make([]*Node, width)
+			for x := 0; x < width; x++ {
+				grid.nodes[z][y][x] = NewNode3D(x, y, z)
+			}
+		}
+	}
+
+	return grid, nil
+}
+
+// GetNode3D returns the node at the specified 3D coordinates.
+//
+// Params:
+//
+//	x, y, z: grid coordinates
+//
+// Returns:
+//
+//	*Node: node at the specified position
+//	error: if coordinates are out of bounds
+func (g *Grid3D) GetNode3D(x, y, z int) (*Node, error) {
+	if !g.IsValidPosition3D(x, y, z) {
+		return nil, fmt.Errorf("position (%d, %d, %d) is out of bounds for grid %dx%dx%d", x, y, z, g.Width, g.Height, g.Depth)
+	}
+	return g.nodes[z][y][x], nil
+}
+
+// SetObstacle3D marks a voxel as an obstacle.
+//
+// Params:
+//
+//	x, y, z: grid coordinates
+//
+// Returns:
+//
+//	error: if coordinates are out of bounds
+func (g *Grid3D) SetObstacle3D(x, y, z int) error {
+	node, err := g.GetNode3D(x, y, z)
+	if err != nil {
+		return err
+	}
+	node.IsObstacle = true
+	return nil
+}
+
+// ClearObstacle3D removes obstacle status from a voxel.
+//
+// Params:
+//
+//	x, y, z: grid coordinates
+//
+// Returns:
+//
+//	error: if coordinates are out of bounds
+func (g *Grid3D) ClearObstacle3D(x, y, z int) error {
+	node, err := g.GetNode3D(x, y, z)
+	if err != nil {
+		return err
+	}
+	node.IsObstacle = false
+	return nil
+}
+
+// IsObstacle3D checks if a voxel contains an obstacle.
+//
+// Params:
+//
+//	x, y, z: grid coordinates
+//
+// Returns:
+//
+//	bool: true if position is an obstacle or out of bounds
+func (g *Grid3D) IsObstacle3D(x, y, z int) bool {
+	if !g.IsValidPosition3D(x, y, z) {
+		return true // Out of bounds considered as obstacle
+	}
+	return g.nodes[z][y][x].IsObstacle
+}
+
+// IsValidPosition3D checks if coordinates are within grid bounds.
+//
+// Params:
+//
+//	x, y, z: grid coordinates
+//
+// Returns:
+//
+//	bool: true if position is within bounds
+func (g *Grid3D) IsValidPosition3D(x, y, z int) bool {
+	return x >= 0 && x < g.Width && y >= 0 && y < g.Height && z >= 0 && z < g.Depth
+}
+
+// GetNeighbors returns the walkable neighbors of a voxel under the grid's
+// connectivity. Like Grid, diagonal moves may cut past blocked voxels.
+//
+// Params:
+//
+//	node: the node to get neighbors for
+//
+// Returns:
+//
+//	[]*Node: slice of neighboring nodes
+func (g *Grid3D) GetNeighbors(node *Node) []*Node {
+	neighbors := make([]*Node, 0, 26) // Pre-allocate for max 26 neighbors
+
+	for _, dir := range g.getDirections() {
+		newX := node.X + dir[0]
+		newY := node.Y + dir[1]
+		newZ := node.Z + dir[2]
+
+		if g.IsValidPosition3D(newX, newY, newZ) && !g.nodes[newZ][newY][newX].IsObstacle {
+			neighbors = append(neighbors, g.nodes[newZ][newY][newX])
+		}
+	}
+
+	return neighbors
+}
+
+// GetCost returns the movement cost between two adjacent voxels: 1 across
+// a face, √2 across an edge and √3 across a corner, multiplied by the
+// destination's Cost.
+//
+// Params:
+//
+//	from, to: source and destination nodes
+//
+// Returns:
+//
+//	float64: movement cost
+func (g *Grid3D) GetCost(from, to *Node) float64 {
+	axes := 0
+	if to.X != from.X {
+		axes++
+	}
+	if to.Y != from.Y {
+		axes++
+	}
+	if to.Z != from.Z {
+		axes++
+	}
+
+	baseCost := straightCost3D
+	switch axes {
+	case 2:
+		baseCost = edgeDiagonalCost
+	case 3:
+		baseCost = cornerDiagonalCost
+	}
+
+	return baseCost * to.Cost
+}
+
+// HasUniformCost reports whether every walkable voxel has a cost of 1.0.
+//
+// Returns:
+//
+//	bool: true if all walkable nodes have Cost == 1.0
+func (g *Grid3D) HasUniformCost() bool {
+	for _, layer := range g.nodes {
+		for _, row := range layer {
+			for _, node := range row {
+				if !node.IsObstacle && node.Cost != 1.0 {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Reset clears all algorithm state from all nodes in the grid.
+func (g *Grid3D) Reset() {
+	for _, layer := range g.nodes {
+		for _, row := range layer {
+			for _, node := range row {
+				node.Reset()
+			}
+		}
+	}
+}
+
+// getDirections returns the movement directions for the connectivity as
+// [dx, dy, dz] offsets: every offset in {-1, 0, 1}³ except the origin that
+// changes at most 1, 2 or 3 axes.
+func (g *Grid3D) getDirections() [][3]int {
+	maxAxes := 1
+	switch g.Connectivity {
+	case Connect18:
+		maxAxes = 2
+	case Connect26:
+		maxAxes = 3
+	}
+
+	directions := make([][3]int, 0, 26)
+	for dz := -1; dz <= 1; dz++ {
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				axes := abs(dx) + abs(dy) + abs(dz)
+				if axes > 0 && axes <= maxAxes {
+					directions = append(directions, [3]int{dx, dy, dz})
+				}
+			}
+		}
+	}
+	return directions
+}
+
+// String returns a string representation of the grid for debugging, one
+// layer at a time from z = 0 upwards. Obstacles are shown as '#', empty
+// cells as '.'.
+func (g *Grid3D) String() string {
+	result := fmt.Sprintf("Grid3D %dx%dx%d (%s connectivity):\n", g.Width, g.Height, g.Depth, g.connectivityString())
+
+	for z := 0; z < g.Depth; z++ {
+		result += fmt.Sprintf("z=%d\n", z)
+		for y := 0; y < g.Height; y++ {
+			for x := 0; x < g.Width; x++ {
+				if g.nodes[z][y][x].IsObstacle {
+					result += "#"
+				} else {
+					result += "."
+				}
+			}
+			result += "\n"
+		}
+	}
+
+	return result
+}
+
+// connectivityString returns a string representation of the connectivity
+func (g *Grid3D) connectivityString() string {
+	switch g.Connectivity {
+	case Connect6:
+		return "6-way"
+	case Connect18:
+		return "18-way"
+	case Connect26:
+		return "26-way"
+	default:
+		return "unknown"
+	}
+}
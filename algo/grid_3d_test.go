@@ -0,0 +1,335 @@
+package algo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewGrid3D(t *testing.T) {
+	if _, err := NewGrid3D(0, 5, 5, Connect6); err == nil {
+		t.Error("Expected error for zero width")
+	}
+	if _, err := NewGrid3D(5, 5, -1, Connect6); err == nil {
+		t.Error("Expected error for negative depth")
+	}
+
+	grid, err := NewGrid3D(4, 3, 2, Connect26)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	node, err := grid.GetNode3D(3, 2, 1)
+	if err != nil {
+		t.Fatalf("GetNode3D returned error: %v", err)
+	}
+	if node.X != 3 || node.Y != 2 || node.Z != 1 {
+		t.Errorf("Expected node at (3, 2, 1), got (%d, %d, %d)", node.X, node.Y, node.Z)
+	}
+	if _, err := grid.GetNode3D(0, 0, 2); err == nil {
+		t.Error("Expected error for out of bounds layer")
+	}
+
+	grid.SetObstacle3D(1, 1, 1)
+	if !grid.IsObstacle3D(1, 1, 1) || grid.IsObstacle3D(1, 1, 0) {
+		t.Error("SetObstacle3D should only block the given layer")
+	}
+	grid.ClearObstacle3D(1, 1, 1)
+	if grid.IsObstacle3D(1, 1, 1) {
+		t.Error("ClearObstacle3D should remove the obstacle")
+	}
+	if !grid.IsObstacle3D(-1, 0, 0) {
+		t.Error("Out-of-bounds positions should be obstacles")
+	}
+	if err := grid.SetObstacle3D(0, 0, 5); err == nil {
+		t.Error("SetObstacle3D should return error out of bounds")
+	}
+}
+
+func TestGrid3DNeighbors(t *testing.T) {
+	tests := []struct {
+		connectivity     Connectivity3D
+		interior, corner int
+	}{
+		{Connect6, 6, 3},
+		{Connect18, 18, 6},
+		{Connect26, 26, 7},
+	}
+
+	for _, tt := range tests {
+		grid, _ := NewGrid3D(3, 3, 3, tt.connectivity)
+
+		center, _ := grid.GetNode3D(1, 1, 1)
+		if n := len(grid.GetNeighbors(center)); n != tt.interior {
+			t.Errorf("%s: expected %d interior neighbors, got %d", grid.connectivityString(), tt.interior, n)
+		}
+		corner, _ := grid.GetNode3D(0, 0, 0)
+		if n := len(grid.GetNeighbors(corner)); n != tt.corner {
+			t.Errorf("%s: expected %d corner neighbors, got %d", grid.connectivityString(), tt.corner, n)
+		}
+
+		grid.SetObstacle3D(1, 1, 2)
+		if n := len(grid.GetNeighbors(center)); n != tt.interior-1 {
+			t.Errorf("%s: expected %d neighbors next to an obstacle, got %d", grid.connectivityString(), tt.interior-1, n)
+		}
+	}
+}
+
+func TestGrid3DGetCost(t *testing.T) {
+	grid, _ := NewGrid3D(3, 3, 3, Connect26)
+	from, _ := grid.GetNode3D(1, 1, 1)
+
+	tests := []struct {
+		x, y, z  int
+		expected float64
+	}{
+		{1, 1, 2, 1.0},
+		{2, 1, 2, 1.414},
+		{2, 0, 2, 1.732},
+	}
+	for _, tt := range tests {
+		to, _ := grid.GetNode3D(tt.x, tt.y, tt.z)
+		if cost := grid.GetCost(from, to); cost != tt.expected {
+			t.Errorf("GetCost to (%d, %d, %d) = %.3f, expected %.3f", tt.x, tt.y, tt.z, cost, tt.expected)
+		}
+	}
+
+	// Per-voxel costs multiply the cost of entering the voxel
+	heavy, _ := grid.GetNode3D(2, 0, 2)
+	heavy.Cost = 3
+	if cost := grid.GetCost(from, heavy); math.Abs(cost-3*1.732) > 1e-9 {
+		t.Errorf("Expected weighted corner cost %.3f, got %.3f", 3*1.732, cost)
+	}
+	if grid.HasUniformCost() {
+		t.Error("HasUniformCost() should be false with a weighted voxel")
+	}
+}
+
+func TestHeuristics3D(t *testing.T) {
+	a := NewNode3D(1, 2, 3)
+	b := NewNode3D(4, 0, 9)
+
+	if h := Manhattan3D(a, b); h != 11 {
+		t.Errorf("Manhattan3D = %.3f, expected 11", h)
+	}
+	if h := Euclidean3D(a, b); math.Abs(h-7) > 1e-9 {
+		t.Errorf("Euclidean3D = %.3f, expected 7", h)
+	}
+	// Distances 3, 2, 6: 2 corner, 1 edge and 3 face steps
+	if h, want := Octile3D(a, b), 2*1.732+1.414+3; math.Abs(h-want) > 1e-9 {
+		t.Errorf("Octile3D = %.3f, expected %.3f", h, want)
+	}
+	if Octile3D(a, b) != Octile3D(b, a) {
+		t.Error("Octile3D should be symmetric")
+	}
+}
+
+// TestHeuristics3D_Exact checks that each heuristic matches the true cost
+// on an empty grid with the connectivity it is designed for, and never
+// overestimates it
+func TestHeuristics3D_Exact(t *testing.T) {
+	tests := []struct {
+		connectivity Connectivity3D
+		exact        HeuristicFunc
+	}{
+		{Connect6, Manhattan3D},
+		{Connect26, Octile3D},
+	}
+
+	for _, tt := range tests {
+		grid, _ := NewGrid3D(5, 4, 3, tt.connectivity)
+		start, _ := grid.GetNode3D(0, 0, 0)
+
+		astar := NewAStar()
+		astar.SetGrid(grid)
+		astar.SetHeuristic(Zero)
+
+		for z := 0; z < grid.Depth; z++ {
+			for y := 0; y < grid.Height; y++ {
+				for x := 0; x < grid.Width; x++ {
+					goal, _ := grid.GetNode3D(x, y, z)
+					result, err := astar.Search(start, goal)
+					if err != nil {
+						t.Fatalf("Search() returned error: %v", err)
+					}
+					if h := tt.exact(start, goal); math.Abs(h-result.Cost) > 1e-9 {
+						t.Errorf("%s: heuristic to (%d, %d, %d) = %.3f, true cost %.3f",
+							grid.connectivityString(), x, y, z, h, result.Cost)
+					}
+					// GetCost rounds √2 and √3 down to three decimals, so the
+					// exact straight-line distance may exceed it slightly
+					if h := Euclidean3D(start, goal); h > result.Cost*1.001 {
+						t.Errorf("Euclidean3D overestimates to (%d, %d, %d)", x, y, z)
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestGrid3D_AStar tests a drone path between floors of a building whose
+// only opening is a stairwell, against an uninformed search
+func TestGrid3D_AStar(t *testing.T) {
+	for _, connectivity := range []Connectivity3D{Connect6, Connect18, Connect26} {
+		grid, _ := NewGrid3D(8, 8, 3, connectivity)
+
+		// Solid ceiling between floors 0 and 2, except a stairwell at (7, 7)
+		for y := 0; y < grid.Height; y++ {
+			for x := 0; x < grid.Width; x++ {
+				if x != 7 || y != 7 {
+					grid.SetObstacle3D(x, y, 1)
+				}
+			}
+		}
+		// A costly region on the upper floor
+		for y := 2; y < 6; y++ {
+			node, _ := grid.GetNode3D(6, y, 2)
+			node.Cost = 5
+		}
+
+		start, _ := grid.GetNode3D(0, 0, 0)
+		goal, _ := grid.GetNode3D(0, 4, 2)
+
+		heuristic := Octile3D
+		if connectivity == Connect6 {
+			heuristic = Manhattan3D
+		}
+
+		astar := NewAStar()
+		astar.SetGrid(grid)
+		astar.SetHeuristic(heuristic)
+		result, err := astar.Search(start, goal)
+		if err != nil {
+			t.Fatalf("%s: Search() returned error: %v", grid.connectivityString(), err)
+		}
+
+		if !result.Path[0].Equals(start) || !result.Path[len(result.Path)-1].Equals(goal) {
+			t.Fatalf("%s: path does not run from start to goal", grid.connectivityString())
+		}
+		passedStairwell := false
+		for i := 1; i < len(result.Path); i++ {
+			from, to := result.Path[i-1], result.Path[i]
+			axes := abs(to.X-from.X) + abs(to.Y-from.Y) + abs(to.Z-from.Z)
+			if axes == 0 || abs(to.X-from.X) > 1 || abs(to.Y-from.Y) > 1 || abs(to.Z-from.Z) > 1 {
+				t.Fatalf("%s: path step %d is not between adjacent voxels", grid.connectivityString(), i)
+			}
+			if to.IsObstacle {
+				t.Fatalf("%s: path crosses an obstacle at step %d", grid.connectivityString(), i)
+			}
+			if to.Z == 1 {
+				passedStairwell = true
+			}
+		}
+		if !passedStairwell {
+			t.Errorf("%s: path should climb through the stairwell", grid.connectivityString())
+		}
+
+		reference := NewAStar()
+		reference.SetGrid(grid)
+		reference.SetHeuristic(Zero)
+		want, err := reference.Search(start, goal)
+		if err != nil {
+			t.Fatalf("%s: reference Search() returned error: %v", grid.connectivityString(), err)
+		}
+		if math.Abs(result.Cost-want.Cost) > 1e-9 {
+			t.Errorf("%s: A* cost %.3f, uninformed cost %.3f", grid.connectivityString(), result.Cost, want.Cost)
+		}
+		if result.NodesExpanded > want.NodesExpanded {
+			t.Errorf("%s: A* expanded %d nodes, more than the uninformed %d",
+				grid.connectivityString(), result.NodesExpanded, want.NodesExpanded)
+		}
+	}
+}
+
+// TestGrid3D_Pathfinders tests that the general pathfinders tell voxels on
+// different layers apart when the only route climbs over a wall
+func TestGrid3D_Pathfinders(t *testing.T) {
+	pathfinders := map[string]Pathfinder{
+		"AStar":              NewAStar(),
+		"Dijkstra":           NewDijkstra(),
+		"BFS":                NewBFS(),
+		"GreedyBestFirst":    NewGreedyBestFirst(),
+		"BidirectionalAStar": NewBidirectionalAStar(),
+		"IDAStar":            NewIDAStar(),
+		"ARAStar":            NewARAStar(),
+		"KShortest":          NewKShortest(),
+	}
+
+	for name, pathfinder := range pathfinders {
+		t.Run(name, func(t *testing.T) {
+			grid, _ := NewGrid3D(3, 1, 3, Connect6)
+			grid.SetObstacle3D(1, 0, 0)
+			start, _ := grid.GetNode3D(0, 0, 0)
+			goal, _ := grid.GetNode3D(2, 0, 0)
+
+			pathfinder.SetGrid(grid)
+			pathfinder.SetHeuristic(Manhattan3D)
+			path, err := pathfinder.FindPath(start, goal)
+			if err != nil {
+				t.Fatalf("FindPath() returned error: %v", err)
+			}
+			if len(path) != 5 || path[2].Z != 1 {
+				t.Errorf("Expected a 5-voxel path over layer 1, got %v", path)
+			}
+		})
+	}
+}
+
+// TestGrid3D_IncrementalPlanners tests that D* Lite and LPA* tell voxels on
+// different layers apart when routing over a wall on the floor layer
+func TestGrid3D_IncrementalPlanners(t *testing.T) {
+	grid, _ := NewGrid3D(3, 1, 3, Connect6)
+	grid.SetObstacle3D(1, 0, 0)
+	start, _ := grid.GetNode3D(0, 0, 0)
+	goal, _ := grid.GetNode3D(2, 0, 0)
+
+	dstar := NewDStarLite()
+	dstar.SetGrid(grid)
+	dstar.SetHeuristic(Manhattan3D)
+	lpa := NewLPAStar()
+	lpa.SetGrid(grid)
+	lpa.SetHeuristic(Manhattan3D)
+
+	dstarPath, err := dstar.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("D* Lite FindPath() returned error: %v", err)
+	}
+	lpaPath, err := lpa.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("LPA* FindPath() returned error: %v", err)
+	}
+	for name, path := range map[string][]*Node{"DStarLite": dstarPath, "LPAStar": lpaPath} {
+		if len(path) != 5 || path[2].Z != 1 {
+			t.Errorf("%s: expected a 5-voxel path over layer 1, got %v", name, path)
+		}
+	}
+
+	// Block layer 1 above the wall; the path climbs to layer 2 instead
+	grid.SetObstacle3D(1, 0, 1)
+	blocked, _ := grid.GetNode3D(1, 0, 1)
+	dstar.NotifyChanged(blocked)
+	lpa.NotifyChanged(blocked)
+
+	dstarPath, err = dstar.Replan()
+	if err != nil {
+		t.Fatalf("D* Lite Replan() returned error: %v", err)
+	}
+	lpaPath, err = lpa.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("LPA* FindPath() returned error: %v", err)
+	}
+	for name, path := range map[string][]*Node{"DStarLite": dstarPath, "LPAStar": lpaPath} {
+		if len(path) != 7 || path[3].Z != 2 {
+			t.Errorf("%s: expected a 7-voxel path over layer 2, got %v", name, path)
+		}
+	}
+}
+
+func TestGrid3DString(t *testing.T) {
+	grid, _ := NewGrid3D(2, 1, 2, Connect6)
+	grid.SetObstacle3D(1, 0, 1)
+
+	want := "Grid3D 2x1x2 (6-way connectivity):\nz=0\n..\nz=1\n.#\n"
+	if got := grid.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -1,6 +1,7 @@
 package algo
 
 import (
+	"math"
 	"testing"
 )
 
@@ -289,3 +290,188 @@ func BenchmarkGetNeighbors8Way(b *testing.B) {
 		_ = grid.GetNeighbors(node)
 	}
 }
+
+func TestGridHasUniformCost(t *testing.T) {
+	grid, _ := NewGrid(4, 4, FourWay)
+
+	if !grid.HasUniformCost() {
+		t.Error("New grid should have uniform cost")
+	}
+
+	// Obstacles are never entered, so their cost does not matter
+	obstacle, _ := grid.GetNode(1, 1)
+	obstacle.IsObstacle = true
+	obstacle.Cost = 5.0
+	if !grid.HasUniformCost() {
+		t.Error("Obstacle cost should not affect uniformity")
+	}
+
+	node, _ := grid.GetNode(2, 3)
+	node.Cost = 2.0
+	if grid.HasUniformCost() {
+		t.Error("Grid with weighted node should not have uniform cost")
+	}
+}
+
+func TestGridLineOfSight(t *testing.T) {
+	grid, _ := NewGrid(6, 6, EightWay)
+	grid.SetObstacle(2, 1)
+
+	tests := []struct {
+		name           string
+		x0, y0, x1, y1 int
+		expected       bool
+	}{
+		{"same cell", 0, 0, 0, 0, true},
+		{"clear horizontal", 0, 0, 5, 0, true},
+		{"blocked horizontal", 0, 1, 5, 1, false},
+		{"clear diagonal", 0, 0, 5, 5, true},
+		{"shallow line through obstacle", 0, 0, 5, 2, false},
+		{"shallow line clearing obstacle", 0, 2, 5, 3, true},
+		{"endpoint is obstacle", 0, 0, 2, 1, false},
+		{"out of bounds", 0, 0, 6, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := grid.LineOfSight(tt.x0, tt.y0, tt.x1, tt.y1); got != tt.expected {
+				t.Errorf("LineOfSight(%d,%d -> %d,%d) = %v, expected %v",
+					tt.x0, tt.y0, tt.x1, tt.y1, got, tt.expected)
+			}
+			// Line of sight is symmetric
+			if got := grid.LineOfSight(tt.x1, tt.y1, tt.x0, tt.y0); got != tt.expected {
+				t.Errorf("LineOfSight(%d,%d -> %d,%d) = %v, expected %v",
+					tt.x1, tt.y1, tt.x0, tt.y0, got, tt.expected)
+			}
+		})
+	}
+}
+
+// newSqueezeGrid returns a 3x3 EightWay grid whose corner cell (0,0) is
+// walled off by the obstacles (1,0) and (0,1):
+//
+//	. # .
+//	# . .
+//	. . .
+func newSqueezeGrid(policy DiagonalPolicy) *Grid {
+	grid, _ := NewGrid(3, 3, EightWay)
+	grid.DiagonalPolicy = policy
+	grid.SetObstacle(1, 0)
+	grid.SetObstacle(0, 1)
+	return grid
+}
+
+func TestGridDiagonalPolicy(t *testing.T) {
+	// From the center, the diagonal to (0,0) passes two obstacles, those to
+	// (2,0) and (0,2) pass one, and the one to (2,2) passes none
+	tests := []struct {
+		name      string
+		policy    DiagonalPolicy
+		neighbors [][2]int
+	}{
+		{"always", DiagonalAlways, [][2]int{{2, 1}, {1, 2}, {0, 0}, {2, 0}, {0, 2}, {2, 2}}},
+		{"never", DiagonalNever, [][2]int{{2, 1}, {1, 2}}},
+		{"if no obstacles", DiagonalIfNoObstacles, [][2]int{{2, 1}, {1, 2}, {2, 2}}},
+		{"if at most one obstacle", DiagonalIfAtMostOneObstacle, [][2]int{{2, 1}, {1, 2}, {2, 0}, {0, 2}, {2, 2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid := newSqueezeGrid(tt.policy)
+			center, _ := grid.GetNode(1, 1)
+
+			neighbors := grid.GetNeighbors(center)
+			if len(neighbors) != len(tt.neighbors) {
+				t.Fatalf("Expected %d neighbors, got %d", len(tt.neighbors), len(neighbors))
+			}
+			allowed := make(map[[2]int]bool)
+			for _, exp := range tt.neighbors {
+				allowed[exp] = true
+			}
+			for _, neighbor := range neighbors {
+				if !allowed[[2]int{neighbor.X, neighbor.Y}] {
+					t.Errorf("Unexpected neighbor at (%d, %d)", neighbor.X, neighbor.Y)
+				}
+			}
+
+			// GetCost agrees with GetNeighbors on every diagonal
+			for _, d := range [][2]int{{0, 0}, {2, 0}, {0, 2}, {2, 2}} {
+				to, _ := grid.GetNode(d[0], d[1])
+				cost := grid.GetCost(center, to)
+				if allowed[d] && cost != 1.414 {
+					t.Errorf("Expected cost 1.414 to (%d, %d), got %.3f", d[0], d[1], cost)
+				}
+				if !allowed[d] && !math.IsInf(cost, 1) {
+					t.Errorf("Expected +Inf cost to (%d, %d), got %.3f", d[0], d[1], cost)
+				}
+			}
+		})
+	}
+
+	// The policy does not affect FourWay grids
+	grid4, _ := NewGrid(3, 3, FourWay)
+	grid4.DiagonalPolicy = DiagonalIfNoObstacles
+	center4, _ := grid4.GetNode(1, 1)
+	if n := len(grid4.GetNeighbors(center4)); n != 4 {
+		t.Errorf("Expected 4 neighbors for 4-way movement, got %d", n)
+	}
+}
+
+func TestGridDiagonalPolicyLineOfSight(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  DiagonalPolicy
+		squeeze bool // (0,0) -> (2,2), between two obstacles
+		corner  bool // (2,0) -> (0,2), past one obstacle at each corner
+	}{
+		{"always", DiagonalAlways, true, true},
+		{"never", DiagonalNever, false, false},
+		{"if no obstacles", DiagonalIfNoObstacles, false, false},
+		{"if at most one obstacle", DiagonalIfAtMostOneObstacle, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid := newSqueezeGrid(tt.policy)
+			if got := grid.LineOfSight(0, 0, 2, 2); got != tt.squeeze {
+				t.Errorf("LineOfSight(0,0 -> 2,2) = %v, expected %v", got, tt.squeeze)
+			}
+			if got := grid.LineOfSight(2, 0, 0, 2); got != tt.corner {
+				t.Errorf("LineOfSight(2,0 -> 0,2) = %v, expected %v", got, tt.corner)
+			}
+			// Corners with walkable cells on both sides are always crossable
+			if !grid.LineOfSight(1, 1, 2, 2) {
+				t.Error("LineOfSight(1,1 -> 2,2) should be clear")
+			}
+		})
+	}
+}
+
+func TestGridDiagonalPolicyPath(t *testing.T) {
+	// (0,0) can only leave by squeezing diagonally between the obstacles
+	tests := []struct {
+		policy    DiagonalPolicy
+		reachable bool
+	}{
+		{DiagonalAlways, true},
+		{DiagonalNever, false},
+		{DiagonalIfNoObstacles, false},
+		{DiagonalIfAtMostOneObstacle, false},
+	}
+
+	for _, tt := range tests {
+		grid := newSqueezeGrid(tt.policy)
+		astar := NewAStar()
+		astar.SetGrid(grid)
+		start, _ := grid.GetNode(0, 0)
+		goal, _ := grid.GetNode(2, 2)
+
+		_, err := astar.FindPath(start, goal)
+		if tt.reachable && err != nil {
+			t.Errorf("Policy %s: expected a path, got error %v", grid.diagonalPolicyString(), err)
+		}
+		if !tt.reachable && err == nil {
+			t.Errorf("Policy %s: expected no path", grid.diagonalPolicyString())
+		}
+	}
+}
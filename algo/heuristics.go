@@ -169,6 +169,100 @@ func Zero(current, goal *Node) float64 {
 	return 0.0
 }
 
+// HexDistance calculates the number of steps between two hexagons on a
+// HexGrid, whose nodes hold axial coordinates. It is exact on an empty
+// uniform-cost hex map, and therefore admissible and consistent.
+//
+// Formula: (|dq| + |dr| + |dq + dr|) / 2
+//
+// Params:
+//
+//	current: current node (axial coordinates)
+//	goal: goal node (axial coordinates)
+//
+// Returns:
+//
+//	float64: hex distance
+func HexDistance(current, goal *Node) float64 {
+	dq := current.X - goal.X
+	dr := current.Y - goal.Y
+	return float64(abs(dq)+abs(dr)+abs(dq+dr)) / 2
+}
+
+// Manhattan3D calculates the Manhattan distance between two voxels.
+// This heuristic is optimal for 6-way movement on a Grid3D.
+//
+// Formula: |x1 - x2| + |y1 - y2| + |z1 - z2|
+//
+// Params:
+//
+//	current: current node
+//	goal: goal node
+//
+// Returns:
+//
+//	float64: 3D Manhattan distance
+func Manhattan3D(current, goal *Node) float64 {
+	return float64(abs(current.X-goal.X) + abs(current.Y-goal.Y) + abs(current.Z-goal.Z))
+}
+
+// Euclidean3D calculates the straight-line distance between two voxels.
+// It is admissible for any connectivity but less informed than Octile3D.
+//
+// Formula: sqrt((x1 - x2)² + (y1 - y2)² + (z1 - z2)²)
+//
+// Params:
+//
+//	current: current node
+//	goal: goal node
+//
+// Returns:
+//
+//	float64: 3D Euclidean distance
+func Euclidean3D(current, goal *Node) float64 {
+	dx := float64(current.X - goal.X)
+	dy := float64(current.Y - goal.Y)
+	dz := float64(current.Z - goal.Z)
+
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
+
+// Octile3D calculates the cost of the cheapest obstacle-free path between
+// two voxels under 26-way movement, where steps across edges cost √2 and
+// steps across corners cost √3. With the per-axis distances sorted so that
+// d1 >= d2 >= d3, the path takes d3 corner steps, d2 - d3 edge steps and
+// d1 - d2 face steps. Use Manhattan3D for 6-way movement; under 18-way
+// movement Octile3D is still admissible.
+//
+// Formula: √3 * d3 + √2 * (d2 - d3) + (d1 - d2)
+//
+// Params:
+//
+//	current: current node
+//	goal: goal node
+//
+// Returns:
+//
+//	float64: 3D octile distance
+func Octile3D(current, goal *Node) float64 {
+	d1 := abs(current.X - goal.X)
+	d2 := abs(current.Y - goal.Y)
+	d3 := abs(current.Z - goal.Z)
+
+	// Sort so that d1 >= d2 >= d3
+	if d1 < d2 {
+		d1, d2 = d2, d1
+	}
+	if d2 < d3 {
+		d2, d3 = d3, d2
+	}
+	if d1 < d2 {
+		d1, d2 = d2, d1
+	}
+
+	return cornerDiagonalCost*float64(d3) + edgeDiagonalCost*float64(d2-d3) + straightCost3D*float64(d1-d2)
+}
+
 // GetHeuristicByName returns a heuristic function by name.
 // This is useful for configuration and testing different heuristics.
 //
@@ -178,6 +272,10 @@ func Zero(current, goal *Node) float64 {
 //   - "euclidean_squared": Squared Euclidean distance
 //   - "diagonal": Diagonal/Chebyshev distance
 //   - "diagonal_cost": Diagonal with movement costs
+//   - "hex": Hex distance for HexGrid
+//   - "manhattan3d": 3D Manhattan distance for Grid3D
+//   - "euclidean3d": 3D Euclidean distance for Grid3D
+//   - "octile3d": 3D octile distance for Grid3D
 //   - "zero": Zero heuristic (Dijkstra)
 //
 // Params:
@@ -200,6 +298,14 @@ func GetHeuristicByName(name string) (HeuristicFunc, bool) {
 		return Diagonal, true
 	case "diagonal_cost":
 		return DiagonalWithCost, true
+	case "hex":
+		return HexDistance, true
+	case "manhattan3d":
+		return Manhattan3D, true
+	case "euclidean3d":
+		return Euclidean3D, true
+	case "octile3d":
+		return Octile3D, true
 	case "zero":
 		return Zero, true
 	default:
@@ -219,6 +325,10 @@ func GetSupportedHeuristics() []string {
 		"euclidean_squared",
 		"diagonal",
 		"diagonal_cost",
+		"hex",
+		"manhattan3d",
+		"euclidean3d",
+		"octile3d",
 		"zero",
 	}
 }
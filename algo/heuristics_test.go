@@ -168,6 +168,10 @@ func TestGetHeuristicByName(t *testing.T) {
 		{"euclidean_squared", true, 25.0},
 		{"diagonal", true, 4.0},
 		{"diagonal_cost", true, 5.243}, // 1*(4-3) + 1.414*3 ≈ 5.242
+		{"hex", true, 7.0},
+		{"manhattan3d", true, 7.0},
+		{"euclidean3d", true, 5.0},
+		{"octile3d", true, 5.242}, // 1*(4-3) + 1.414*3
 		{"zero", true, 0.0},
 		{"invalid", false, 0.0},
 		{"", false, 0.0},
@@ -201,7 +205,7 @@ func TestGetHeuristicByName(t *testing.T) {
 func TestGetSupportedHeuristics(t *testing.T) {
 	supported := GetSupportedHeuristics()
 
-	expectedCount := 6
+	expectedCount := 10
 	if len(supported) != expectedCount {
 		t.Errorf("Expected %d supported heuristics, got %d", expectedCount, len(supported))
 	}
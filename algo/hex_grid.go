@@ -0,0 +1,399 @@
+package algo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// HexLayout defines how hexagons are oriented on screen
+type HexLayout int
+
+const (
+	// PointyTop hexagons have a corner at the top; rows are offset
+	PointyTop HexLayout = iota
+	// FlatTop hexagons have an edge at the top; columns are offset
+	FlatTop
+)
+
+// hexDirections are the six axial neighbor offsets (dq, dr).
+var hexDirections = [6][2]int{
+	{1, 0}, {1, -1}, {0, -1},
+	{-1, 0}, {-1, 1}, {0, 1},
+}
+
+// HexGrid represents a rectangular map of hexagons.
+//
+// Nodes are addressed with axial coordinates (q, r), stored in Node.X and
+// Node.Y, so every pathfinder works on hex maps unchanged. The third cube
+// coordinate is implicit: s = -q - r. The map is a width x height rectangle
+// in "odd" offset coordinates (odd rows shifted right for PointyTop, odd
+// columns shifted down for FlatTop); OffsetToAxial and AxialToOffset convert
+// between the two systems.
+//
+// Every neighbor is one step away, so GetCost is the destination's Cost.
+// Use the HexDistance heuristic: Manhattan overestimates on hex maps.
+type HexGrid struct {
+	// Map dimensions in offset coordinates (columns, rows)
+	Width, Height int
+
+	// Layout is the orientation of the hexagons
+	Layout HexLayout
+
+	// 2D array of nodes stored as nodes[row][col] (offset coordinates)
+	nodes [][]*Node
+}
+
+// NewHexGrid creates a new hex map with the specified dimensions in offset
+// coordinates. All nodes are initialized as empty (non-obstacle) nodes.
+//
+// Params:
+//
+//	width, height: number of columns and rows (must be > 0)
+//	layout: hexagon orientation (PointyTop or FlatTop)
+//
+// Returns:
+//
+//	*HexGrid: new hex grid instance
+//	error: if dimensions are invalid
+func NewHexGrid(width, height int, layout HexLayout) (*HexGrid, error) {
+	if width <= 0 || height <= 0 {
+		return nil, errors.New("grid dimensions must be positive")
+	}
+
+	grid := &HexGrid{
+		Width:  width,
+		Height: height,
+		Layout: layout,
+		nodes:  make([][]*Node, height),
+	}
+
+	for row := 0; row < height; row++ {
+		grid.nodes[row] = make([]*Node, width)
+		for col := 0; col < width; col++ {
+			q, r := grid.OffsetToAxial(col, row)
+			grid.nodes[row][col] = NewNode(q, r)
+		}
+	}
+
+	return grid, nil
+}
+
+// OffsetToAxial converts offset coordinates (col, row) to axial (q, r).
+//
+// Params:
+//
+//	col, row: offset coordinates
+//
+// Returns:
+//
+//	int, int: axial coordinates q, r
+func (g *HexGrid) OffsetToAxial(col, row int) (int, int) {
+	if g.Layout == FlatTop {
+		return col, row - (col-(col&1))/2
+	}
+	return col - (row-(row&1))/2, row
+}
+
+// AxialToOffset converts axial coordinates (q, r) to offset (col, row).
+//
+// Params:
+//
+//	q, r: axial coordinates
+//
+// Returns:
+//
+//	int, int: offset coordinates col, row
+func (g *HexGrid) AxialToOffset(q, r int) (int, int) {
+	if g.Layout == FlatTop {
+		return q, r + (q-(q&1))/2
+	}
+	return q + (r-(r&1))/2, r
+}
+
+// AxialToCube converts axial coordinates to cube coordinates (x, y, z)
+// with x + y + z = 0.
+//
+// Params:
+//
+//	q, r: axial coordinates
+//
+// Returns:
+//
+//	int, int, int: cube coordinates
+func AxialToCube(q, r int) (int, int, int) {
+	return q, -q - r, r
+}
+
+// CubeToAxial converts cube coordinates to axial coordinates.
+//
+// Params:
+//
+//	x, y, z: cube coordinates (x + y + z must be 0)
+//
+// Returns:
+//
+//	int, int: axial coordinates q, r
+func CubeToAxial(x, y, z int) (int, int) {
+	return x, z
+}
+
+// GetNode returns the node at the specified axial coordinates.
+//
+// Params:
+//
+//	q, r: axial coordinates
+//
+// Returns:
+//
+//	*Node: node at the specified position
+//	error: if coordinates are outside the map
+func (g *HexGrid) GetNode(q, r int) (*Node, error) {
+	if !g.IsValidPosition(q, r) {
+		return nil, fmt.Errorf("position (%d, %d) is out of bounds for hex grid %dx%d", q, r, g.Width, g.Height)
+	}
+	col, row := g.AxialToOffset(q, r)
+	return g.nodes[row][col], nil
+}
+
+// GetNodeAtOffset returns the node at the specified offset coordinates.
+//
+// Params:
+//
+//	col, row: offset coordinates
+//
+// Returns:
+//
+//	*Node: node at the specified position
+//	error: if coordinates are outside the map
+func (g *HexGrid) GetNodeAtOffset(col, row int) (*Node, error) {
+	if col < 0 || col >= g.Width || row < 0 || row >= g.Height {
+		return nil, fmt.Errorf("offset position (%d, %d) is out of bounds for hex grid %dx%d", col, row, g.Width, g.Height)
+	}
+	return g.nodes[row][col], nil
+}
+
+// SetObstacle marks a node as an obstacle.
+//
+// Params:
+//
+//	q, r: axial coordinates
+//
+// Returns:
+//
+//	error: if coordinates are outside the map
+func (g *HexGrid) SetObstacle(q, r int) error {
+	node, err := g.GetNode(q, r)
+	if err != nil {
+		return err
+	}
+	node.IsObstacle = true
+	return nil
+}
+
+// ClearObstacle removes obstacle status from a node.
+//
+// Params:
+//
+//	q, r: axial coordinates
+//
+// Returns:
+//
+//	error: if coordinates are outside the map
+func (g *HexGrid) ClearObstacle(q, r int) error {
+	node, err := g.GetNode(q, r)
+	if err != nil {
+		return err
+	}
+	node.IsObstacle = false
+	return nil
+}
+
+// IsObstacle checks if a position contains an obstacle.
+//
+// Params:
+//
+//	q, r: axial coordinates
+//
+// Returns:
+//
+//	bool: true if position is an obstacle or outside the map
+func (g *HexGrid) IsObstacle(q, r int) bool {
+	node, err := g.GetNode(q, r)
+	if err != nil {
+		return true // Out of bounds considered as obstacle
+	}
+	return node.IsObstacle
+}
+
+// IsValidPosition checks if axial coordinates lie inside the map.
+//
+// Params:
+//
+//	q, r: axial coordinates
+//
+// Returns:
+//
+//	bool: true if position is within bounds
+func (g *HexGrid) IsValidPosition(q, r int) bool {
+	col, row := g.AxialToOffset(q, r)
+	return col >= 0 && col < g.Width && row >= 0 && row < g.Height
+}
+
+// GetNeighbors returns the walkable nodes among the six adjacent hexagons.
+//
+// Params:
+//
+//	node: the node to get neighbors for
+//
+// Returns:
+//
+//	[]*Node: slice of neighboring nodes
+func (g *HexGrid) GetNeighbors(node *Node) []*Node {
+	neighbors := make([]*Node, 0, 6)
+	for _, dir := range hexDirections {
+		neighbor, err := g.GetNode(node.X+dir[0], node.Y+dir[1])
+		if err == nil && !neighbor.IsObstacle {
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+	return neighbors
+}
+
+// GetCost returns the movement cost from one node to an adjacent one.
+// All six neighbors are equally far, so this is the destination's Cost.
+//
+// Params:
+//
+//	from, to: source and destination nodes
+//
+// Returns:
+//
+//	float64: movement cost
+func (g *HexGrid) GetCost(from, to *Node) float64 {
+	return to.Cost
+}
+
+// HasUniformCost reports whether every walkable node has a cost of 1.0.
+//
+// Returns:
+//
+//	bool: true if all walkable nodes have Cost == 1.0
+func (g *HexGrid) HasUniformCost() bool {
+	for _, row := range g.nodes {
+		for _, node := range row {
+			if !node.IsObstacle && node.Cost != 1.0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Reset clears all algorithm state from all nodes in the grid.
+func (g *HexGrid) Reset() {
+	for _, row := range g.nodes {
+		for _, node := range row {
+			node.Reset()
+		}
+	}
+}
+
+// Center returns the pixel position of a hexagon's center, with the
+// hexagon at axial (0, 0) centered on the origin.
+//
+// Params:
+//
+//	q, r: axial coordinates
+//	size: distance from a hexagon's center to its corners
+//
+// Returns:
+//
+//	float64, float64: pixel coordinates x, y
+func (g *HexGrid) Center(q, r int, size float64) (float64, float64) {
+	fq, fr := float64(q), float64(r)
+	if g.Layout == FlatTop {
+		return size * 1.5 * fq, size * math.Sqrt(3) * (fr + fq/2)
+	}
+	return size * math.Sqrt(3) * (fq + fr/2), size * 1.5 * fr
+}
+
+// HexAt returns the axial coordinates of the hexagon containing a pixel,
+// the inverse of Center. The result may lie outside the map.
+//
+// Params:
+//
+//	x, y: pixel coordinates
+//	size: distance from a hexagon's center to its corners
+//
+// Returns:
+//
+//	int, int: axial coordinates q, r
+func (g *HexGrid) HexAt(x, y, size float64) (int, int) {
+	var fq, fr float64
+	if g.Layout == FlatTop {
+		fq = (2.0 / 3 * x) / size
+		fr = (-1.0/3*x + math.Sqrt(3)/3*y) / size
+	} else {
+		fq = (math.Sqrt(3)/3*x - 1.0/3*y) / size
+		fr = (2.0 / 3 * y) / size
+	}
+	return hexRound(fq, fr)
+}
+
+// hexRound rounds fractional axial coordinates to the nearest hexagon.
+// Each cube coordinate is rounded, then the one with the largest rounding
+// error is recomputed from the other two so that x + y + z = 0 holds.
+func hexRound(fq, fr float64) (int, int) {
+	fx, fz := fq, fr
+	fy := -fx - fz
+
+	x, y, z := math.Round(fx), math.Round(fy), math.Round(fz)
+	dx, dy, dz := math.Abs(x-fx), math.Abs(y-fy), math.Abs(z-fz)
+
+	switch {
+	case dx > dy && dx > dz:
+		x = -y - z
+	case dy > dz:
+		y = -x - z
+	default:
+		z = -x - y
+	}
+
+	return CubeToAxial(int(x), int(y), int(z))
+}
+
+// String returns a string representation of the map for debugging, row by
+// row in offset coordinates. For PointyTop, shifted rows are indented.
+// Obstacles are shown as '#', empty cells as '.'.
+func (g *HexGrid) String() string {
+	result := fmt.Sprintf("HexGrid %dx%d (%s):\n", g.Width, g.Height, g.layoutString())
+
+	for row := 0; row < g.Height; row++ {
+		if g.Layout == PointyTop && row%2 == 1 {
+			result += " "
+		}
+		for col := 0; col < g.Width; col++ {
+			if g.nodes[row][col].IsObstacle {
+				result += "# "
+			} else {
+				result += ". "
+			}
+		}
+		result += "\n"
+	}
+
+	return result
+}
+
+// layoutString returns a string representation of the layout
+func (g *HexGrid) layoutString() string {
+	switch g.Layout {
+	case PointyTop:
+		return "pointy-top"
+	case FlatTop:
+		return "flat-top"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,227 @@
+package algo
+
+import (
+	"math"
+	"testing"
+)
+
+// hexStepDistances counts steps from start to every reachable hexagon by
+// breadth-first search
+func hexStepDistances(grid *HexGrid, start *Node) map[coordinate]int {
+	dist := map[coordinate]int{{start.X, start.Y}: 0}
+	queue := []*Node{start}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, neighbor := range grid.GetNeighbors(node) {
+			key := coordinate{neighbor.X, neighbor.Y}
+			if _, seen := dist[key]; !seen {
+				dist[key] = dist[coordinate{node.X, node.Y}] + 1
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+	return dist
+}
+
+func TestNewHexGrid(t *testing.T) {
+	if _, err := NewHexGrid(0, 5, PointyTop); err == nil {
+		t.Error("Expected error for zero width")
+	}
+	if _, err := NewHexGrid(5, -1, FlatTop); err == nil {
+		t.Error("Expected error for negative height")
+	}
+
+	for _, layout := range []HexLayout{PointyTop, FlatTop} {
+		grid, err := NewHexGrid(7, 5, layout)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		count := 0
+		for row := 0; row < grid.Height; row++ {
+			for col := 0; col < grid.Width; col++ {
+				node, err := grid.GetNodeAtOffset(col, row)
+				if err != nil {
+					t.Fatalf("GetNodeAtOffset(%d, %d) returned error: %v", col, row, err)
+				}
+				if byAxial, _ := grid.GetNode(node.X, node.Y); byAxial != node {
+					t.Errorf("GetNode(%d, %d) does not return the node at offset (%d, %d)", node.X, node.Y, col, row)
+				}
+				count++
+			}
+		}
+		if count != 35 {
+			t.Errorf("Expected 35 nodes, got %d", count)
+		}
+	}
+}
+
+func TestHexGridCoordinateConversion(t *testing.T) {
+	for _, layout := range []HexLayout{PointyTop, FlatTop} {
+		grid, _ := NewHexGrid(1, 1, layout)
+		for col := -6; col <= 6; col++ {
+			for row := -6; row <= 6; row++ {
+				q, r := grid.OffsetToAxial(col, row)
+				if c, rr := grid.AxialToOffset(q, r); c != col || rr != row {
+					t.Errorf("layout %d: offset (%d, %d) -> axial (%d, %d) -> offset (%d, %d)", layout, col, row, q, r, c, rr)
+				}
+
+				x, y, z := AxialToCube(q, r)
+				if x+y+z != 0 {
+					t.Errorf("Cube coordinates (%d, %d, %d) do not sum to 0", x, y, z)
+				}
+				if aq, ar := CubeToAxial(x, y, z); aq != q || ar != r {
+					t.Errorf("Cube round trip of (%d, %d) gave (%d, %d)", q, r, aq, ar)
+				}
+			}
+		}
+	}
+
+	// Odd rows of a pointy-top map are shifted right: offset (0, 1) is
+	// axial (0, 1), offset (0, 2) is axial (-1, 2)
+	pointy, _ := NewHexGrid(1, 1, PointyTop)
+	if q, r := pointy.OffsetToAxial(0, 2); q != -1 || r != 2 {
+		t.Errorf("Expected pointy-top offset (0, 2) at axial (-1, 2), got (%d, %d)", q, r)
+	}
+
+	// Odd columns of a flat-top map are shifted down
+	flat, _ := NewHexGrid(1, 1, FlatTop)
+	if q, r := flat.OffsetToAxial(2, 0); q != 2 || r != -1 {
+		t.Errorf("Expected flat-top offset (2, 0) at axial (2, -1), got (%d, %d)", q, r)
+	}
+}
+
+func TestHexGridNeighbors(t *testing.T) {
+	for _, layout := range []HexLayout{PointyTop, FlatTop} {
+		grid, _ := NewHexGrid(5, 5, layout)
+
+		center, _ := grid.GetNodeAtOffset(2, 2)
+		neighbors := grid.GetNeighbors(center)
+		if len(neighbors) != 6 {
+			t.Fatalf("Expected 6 neighbors for an interior hexagon, got %d", len(neighbors))
+		}
+		for _, n := range neighbors {
+			if HexDistance(center, n) != 1 {
+				t.Errorf("Neighbor (%d, %d) is not adjacent to (%d, %d)", n.X, n.Y, center.X, center.Y)
+			}
+		}
+
+		corner, _ := grid.GetNodeAtOffset(0, 0)
+		if n := len(grid.GetNeighbors(corner)); n != 2 && n != 3 {
+			t.Errorf("Expected 2 or 3 neighbors for a corner hexagon, got %d", n)
+		}
+
+		grid.SetObstacle(neighbors[0].X, neighbors[0].Y)
+		if n := len(grid.GetNeighbors(center)); n != 5 {
+			t.Errorf("Expected 5 neighbors next to an obstacle, got %d", n)
+		}
+		if !grid.IsObstacle(neighbors[0].X, neighbors[0].Y) {
+			t.Error("IsObstacle() should report the obstacle")
+		}
+		grid.ClearObstacle(neighbors[0].X, neighbors[0].Y)
+		if grid.IsObstacle(neighbors[0].X, neighbors[0].Y) {
+			t.Error("ClearObstacle() should remove the obstacle")
+		}
+
+		if !grid.IsObstacle(100, 100) {
+			t.Error("Out-of-bounds positions should be obstacles")
+		}
+		if err := grid.SetObstacle(100, 100); err == nil {
+			t.Error("SetObstacle() should return error out of bounds")
+		}
+	}
+}
+
+func TestHexDistance(t *testing.T) {
+	grid, _ := NewHexGrid(9, 9, PointyTop)
+	start, _ := grid.GetNodeAtOffset(4, 4)
+
+	// On an empty map the heuristic is exact
+	for key, steps := range hexStepDistances(grid, start) {
+		node, _ := grid.GetNode(key.x, key.y)
+		if h := HexDistance(start, node); h != float64(steps) {
+			t.Errorf("HexDistance to (%d, %d) = %.0f, expected %d", key.x, key.y, h, steps)
+		}
+	}
+}
+
+func TestHexGridPixelConversion(t *testing.T) {
+	const size = 10.0
+
+	for _, layout := range []HexLayout{PointyTop, FlatTop} {
+		grid, _ := NewHexGrid(6, 6, layout)
+
+		for q := -3; q <= 3; q++ {
+			for r := -3; r <= 3; r++ {
+				x, y := grid.Center(q, r, size)
+				if hq, hr := grid.HexAt(x, y, size); hq != q || hr != r {
+					t.Errorf("layout %d: center of (%d, %d) maps back to (%d, %d)", layout, q, r, hq, hr)
+				}
+				// Points well inside the hexagon map to it too
+				if hq, hr := grid.HexAt(x+size/3, y-size/3, size); hq != q || hr != r {
+					t.Errorf("layout %d: point near (%d, %d) maps to (%d, %d)", layout, q, r, hq, hr)
+				}
+			}
+		}
+
+		// Adjacent centers are sqrt(3) * size apart
+		x0, y0 := grid.Center(0, 0, size)
+		x1, y1 := grid.Center(1, 0, size)
+		if d := math.Hypot(x1-x0, y1-y0); math.Abs(d-math.Sqrt(3)*size) > 1e-9 {
+			t.Errorf("layout %d: neighbor spacing %.3f, expected %.3f", layout, d, math.Sqrt(3)*size)
+		}
+	}
+}
+
+// TestHexGrid_AStar tests that AStar runs unchanged on a hex map with the
+// hex distance heuristic and matches Dijkstra
+func TestHexGrid_AStar(t *testing.T) {
+	for _, layout := range []HexLayout{PointyTop, FlatTop} {
+		grid, _ := NewHexGrid(12, 10, layout)
+		for row := 0; row < 8; row++ {
+			grid.nodes[row][5].IsObstacle = true
+		}
+		grid.nodes[3][8].Cost = 4
+
+		start, _ := grid.GetNodeAtOffset(1, 1)
+		goal, _ := grid.GetNodeAtOffset(10, 2)
+
+		astar := NewAStar()
+		astar.SetGrid(grid)
+		astar.SetHeuristic(HexDistance)
+		result, err := astar.Search(start, goal)
+		if err != nil {
+			t.Fatalf("Search() returned error: %v", err)
+		}
+
+		for i := 1; i < len(result.Path); i++ {
+			if HexDistance(result.Path[i-1], result.Path[i]) != 1 {
+				t.Fatalf("Path step %d is not between adjacent hexagons", i)
+			}
+			if result.Path[i].IsObstacle {
+				t.Fatalf("Path crosses an obstacle at step %d", i)
+			}
+		}
+
+		dijkstra := NewDijkstra()
+		dijkstra.SetGrid(grid)
+		reference, err := dijkstra.FindPath(start, goal)
+		if err != nil {
+			t.Fatalf("Dijkstra FindPath() returned error: %v", err)
+		}
+		if want := PathCost(grid, reference); math.Abs(result.Cost-want) > 1e-9 {
+			t.Errorf("layout %d: A* cost %.1f, Dijkstra cost %.1f", layout, result.Cost, want)
+		}
+	}
+}
+
+func TestHexGridString(t *testing.T) {
+	grid, _ := NewHexGrid(3, 2, PointyTop)
+	grid.nodes[1][0].IsObstacle = true
+
+	want := "HexGrid 3x2 (pointy-top):\n. . . \n # . . \n"
+	if got := grid.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
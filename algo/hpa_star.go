@@ -0,0 +1,690 @@
+package algo
+
+import (
+	"fmt"
+	"math"
+)
+
+// hpaWideEntrance is the entrance length from which HPA* places two
+// transitions (one at each end) instead of a single one in the middle.
+const hpaWideEntrance = 6
+
+// hpaCluster is a rectangular block of grid cells.
+type hpaCluster struct {
+	// index is the cluster's position in HPAStar.clusters
+	index int
+
+	// minX, minY, maxX, maxY are the inclusive cell bounds of the cluster
+	minX, minY, maxX, maxY int
+
+	// edges holds the outgoing abstract edges of each entrance in the cluster
+	edges map[coordinate][]hpaEdge
+}
+
+// contains reports whether the cell (x, y) lies inside the cluster.
+func (c *hpaCluster) contains(x, y int) bool {
+	return x >= c.minX && x <= c.maxX && y >= c.minY && y <= c.maxY
+}
+
+// hpaTransition is a pair of adjacent walkable cells in neighboring clusters.
+type hpaTransition struct {
+	a, b *Node
+}
+
+// hpaPair identifies two neighboring clusters by index (lo < hi).
+type hpaPair struct {
+	lo, hi int
+}
+
+// hpaEdge is an edge of the abstract graph together with its concrete path.
+type hpaEdge struct {
+	to   *Node
+	cost float64
+
+	// path is the concrete node path including both endpoints
+	path []*Node
+}
+
+// clusterView restricts a Grid to the cells of a single cluster, so that
+// AStar can compute intra-cluster paths.
+type clusterView struct {
+	grid    *Grid
+	cluster *hpaCluster
+}
+
+// GetNode returns the node at (x, y) if it lies inside the cluster.
+func (v *clusterView) GetNode(x, y int) (*Node, error) {
+	if !v.cluster.contains(x, y) {
+		return nil, fmt.Errorf("position (%d, %d) is outside the cluster", x, y)
+	}
+	return v.grid.GetNode(x, y)
+}
+
+// GetNeighbors returns the grid neighbors of node that lie inside the cluster.
+func (v *clusterView) GetNeighbors(node *Node) []*Node {
+	neighbors := v.grid.GetNeighbors(node)
+	inside := neighbors[:0]
+	for _, neighbor := range neighbors {
+		if v.cluster.contains(neighbor.X, neighbor.Y) {
+			inside = append(inside, neighbor)
+		}
+	}
+	return inside
+}
+
+// IsObstacle reports whether (x, y) is blocked or outside the cluster.
+func (v *clusterView) IsObstacle(x, y int) bool {
+	return !v.cluster.contains(x, y) || v.grid.IsObstacle(x, y)
+}
+
+// GetCost returns the grid's movement cost between two nodes.
+func (v *clusterView) GetCost(from, to *Node) float64 {
+	return v.grid.GetCost(from, to)
+}
+
+// Reset clears search state from the cluster's nodes only.
+func (v *clusterView) Reset() {
+	c := v.cluster
+	for y := c.minY; y <= c.maxY; y++ {
+		for x := c.minX; x <= c.maxX; x++ {
+			v.grid.nodes[y][x].Reset()
+		}
+	}
+}
+
+// HPAStar implements Hierarchical Pathfinding A* (Botea, Müller & Schaeffer, 2004).
+// The grid is partitioned into square clusters. Wherever two neighboring
+// clusters share a run of walkable border cells (an entrance), one or two
+// transitions are placed; the cells of all transitions form an abstract
+// graph whose intra-cluster edges are precomputed with AStar. Queries
+// connect start and goal to the entrances of their clusters, search the
+// small abstract graph and then stitch the cached concrete paths together.
+//
+// Paths are near-optimal: they are restricted to pass through transition
+// cells, so they may be slightly longer than AStar's. Use SetObstacle,
+// ClearObstacle or NotifyChanged to edit the grid; only the clusters around
+// the changed cell are rebuilt. Call Build again after changing the grid's
+// DiagonalPolicy. HPAStar requires a *Grid.
+type HPAStar struct {
+	// grid is the search space we're pathfinding within
+	grid SearchSpace
+
+	// heuristic estimates cost for both abstract and intra-cluster searches
+	heuristic HeuristicFunc
+
+	// clusterSize is the width and height of a cluster in cells
+	clusterSize int
+
+	// clusters are stored row-major; cols is the number of clusters per row
+	clusters []*hpaCluster
+	cols     int
+
+	// transitions holds the transitions between each pair of neighboring clusters
+	transitions map[hpaPair][]hpaTransition
+
+	// local computes concrete paths inside a single cluster
+	local *AStar
+
+	// built is true when the abstract graph matches grid, heuristic and cluster size
+	built bool
+}
+
+// NewHPAStar creates a new HPA* pathfinder instance.
+// By default, it uses 10x10 clusters and the Manhattan heuristic; use
+// Diagonal or DiagonalWithCost for EightWay grids.
+//
+// Returns:
+//
+//	*HPAStar: new HPA* pathfinder instance
+func NewHPAStar() *HPAStar {
+	return &HPAStar{
+		heuristic:   Manhattan,
+		clusterSize: 10,
+		local:       NewAStar(),
+	}
+}
+
+// SetGrid configures the grid/search space for the pathfinder.
+// The abstract graph is rebuilt on the next FindPath or Build call.
+//
+// Params:
+//
+//	grid: grid to search within (must be a *Grid)
+func (h *HPAStar) SetGrid(grid SearchSpace) {
+	h.grid = grid
+	h.built = false
+}
+
+// SetHeuristic configures the heuristic function for the pathfinder.
+// The abstract graph is rebuilt on the next FindPath or Build call.
+//
+// Params:
+//
+//	heuristic: heuristic function to use
+func (h *HPAStar) SetHeuristic(heuristic HeuristicFunc) {
+	h.heuristic = heuristic
+	h.built = false
+}
+
+// SetClusterSize configures the width and height of the clusters.
+// Larger clusters give a smaller abstract graph but more expensive
+// preprocessing and per-query connection of start and goal.
+//
+// Params:
+//
+//	size: cluster size in cells (must be > 0)
+//
+// Returns:
+//
+//	error: if size is not positive
+func (h *HPAStar) SetClusterSize(size int) error {
+	if size <= 0 {
+		return fmt.Errorf("cluster size must be > 0, got %d", size)
+	}
+	h.clusterSize = size
+	h.built = false
+	return nil
+}
+
+// Build partitions the grid into clusters and precomputes the abstract
+// graph. FindPath calls it automatically when needed; calling it up front
+// moves the preprocessing cost out of the first query.
+//
+// Returns:
+//
+//	error: if the grid or heuristic is not set, or the grid is not a *Grid
+func (h *HPAStar) Build() error {
+	if h.grid == nil {
+		return fmt.Errorf("grid must be set before calling Build")
+	}
+
+	if h.heuristic == nil {
+		return fmt.Errorf("heuristic must be set before calling Build")
+	}
+
+	grid, ok := h.grid.(*Grid)
+	if !ok {
+		return fmt.Errorf("HPA* requires a *Grid, got %T", h.grid)
+	}
+
+	h.local.SetHeuristic(h.heuristic)
+	h.cols = (grid.Width + h.clusterSize - 1) / h.clusterSize
+	rows := (grid.Height + h.clusterSize - 1) / h.clusterSize
+	h.clusters = make([]*hpaCluster, 0, h.cols*rows)
+	for cy := 0; cy < rows; cy++ {
+		for cx := 0; cx < h.cols; cx++ {
+			h.clusters = append(h.clusters, &hpaCluster{
+				index: len(h.clusters),
+				minX:  cx * h.clusterSize,
+				minY:  cy * h.clusterSize,
+				maxX:  min((cx+1)*h.clusterSize, grid.Width) - 1,
+				maxY:  min((cy+1)*h.clusterSize, grid.Height) - 1,
+			})
+		}
+	}
+
+	h.transitions = make(map[hpaPair][]hpaTransition)
+	for _, c := range h.clusters {
+		for _, n := range h.adjacentClusters(c) {
+			if c.index < n.index {
+				h.transitions[hpaPair{c.index, n.index}] = h.findTransitions(grid, c, n)
+			}
+		}
+	}
+
+	for _, c := range h.clusters {
+		h.buildEdges(grid, c)
+	}
+
+	h.built = true
+	return nil
+}
+
+// SetObstacle marks a cell as an obstacle and rebuilds the affected clusters.
+//
+// Params:
+//
+//	x, y: grid coordinates
+//
+// Returns:
+//
+//	error: if no grid is set or coordinates are out of bounds
+func (h *HPAStar) SetObstacle(x, y int) error {
+	return h.setObstacle(x, y, true)
+}
+
+// ClearObstacle removes obstacle status from a cell and rebuilds the
+// affected clusters.
+//
+// Params:
+//
+//	x, y: grid coordinates
+//
+// Returns:
+//
+//	error: if no grid is set or coordinates are out of bounds
+func (h *HPAStar) ClearObstacle(x, y int) error {
+	return h.setObstacle(x, y, false)
+}
+
+// NotifyChanged informs the pathfinder that the given cells changed, e.g.
+// after editing Node.Cost or toggling obstacles on the grid directly. Only
+// the clusters around these cells are rebuilt. Notifications before the
+// abstract graph is built are ignored.
+//
+// Params:
+//
+//	nodes: cells whose cost or obstacle status changed
+func (h *HPAStar) NotifyChanged(nodes ...*Node) {
+	grid, ok := h.grid.(*Grid)
+	if !h.built || !ok {
+		return
+	}
+
+	for _, node := range nodes {
+		if node != nil && grid.IsValidPosition(node.X, node.Y) {
+			h.update(grid, node.X, node.Y)
+		}
+	}
+}
+
+// AbstractGraphSize returns the number of entrance nodes and edges in the
+// abstract graph, or zeros if it has not been built yet.
+//
+// Returns:
+//
+//	int: number of abstract nodes
+//	int: number of abstract edges
+func (h *HPAStar) AbstractGraphSize() (int, int) {
+	if !h.built {
+		return 0, 0
+	}
+
+	nodes, edges := 0, 0
+	for _, c := range h.clusters {
+		nodes += len(c.edges)
+		for _, out := range c.edges {
+			edges += len(out)
+		}
+	}
+	return nodes, edges
+}
+
+// FindPath finds a near-optimal path from start to goal using HPA*.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	[]*Node: path from start to goal (including both endpoints)
+//	error: if no path exists or invalid input
+func (h *HPAStar) FindPath(start, goal *Node) ([]*Node, error) {
+	result, err := h.Search(start, goal)
+	if err != nil {
+		return nil, err
+	}
+	return result.Path, nil
+}
+
+// Search runs HPA* and returns a detailed result. NodesExpanded counts
+// abstract nodes expanded; HPA* gives no suboptimality bound, so Optimal is
+// false and Bound is +Inf.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	*SearchResult: path, its cost and search statistics
+//	error: if no path exists or invalid input
+func (h *HPAStar) Search(start, goal *Node) (*SearchResult, error) {
+	if err := validateEndpoints(h.grid, start, goal); err != nil {
+		return nil, err
+	}
+
+	if !h.built {
+		if err := h.Build(); err != nil {
+			return nil, err
+		}
+	}
+	grid := h.grid.(*Grid)
+
+	if start.Equals(goal) {
+		return &SearchResult{Path: []*Node{start}, Bound: math.Inf(1)}, nil
+	}
+
+	// Temporarily connect start and goal to the entrances of their clusters
+	extra := make(map[coordinate][]hpaEdge)
+	startCluster := h.clusterOf(start.X, start.Y)
+	goalCluster := h.clusterOf(goal.X, goal.Y)
+
+	for entrance := range startCluster.edges {
+		node := grid.nodes[entrance.y][entrance.x]
+		if edge, ok := h.localEdge(grid, startCluster, start, node); ok {
+			extra[coordinate{start.X, start.Y}] = append(extra[coordinate{start.X, start.Y}], edge)
+		}
+	}
+	for entrance := range goalCluster.edges {
+		node := grid.nodes[entrance.y][entrance.x]
+		if edge, ok := h.localEdge(grid, goalCluster, node, goal); ok {
+			extra[entrance] = append(extra[entrance], edge)
+		}
+	}
+	if startCluster == goalCluster {
+		if edge, ok := h.localEdge(grid, startCluster, start, goal); ok {
+			extra[coordinate{start.X, start.Y}] = append(extra[coordinate{start.X, start.Y}], edge)
+		}
+	}
+
+	edges, expanded, ok := h.searchAbstract(start, goal, extra)
+	if !ok {
+		return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+			start.X, start.Y, goal.X, goal.Y)
+	}
+
+	path := []*Node{start}
+	cost := 0.0
+	for _, edge := range edges {
+		path = append(path, edge.path[1:]...)
+		cost += edge.cost
+	}
+
+	return &SearchResult{
+		Path:          path,
+		Cost:          cost,
+		NodesExpanded: expanded,
+		Bound:         math.Inf(1),
+	}, nil
+}
+
+// searchAbstract runs A* over the abstract graph plus the temporary edges.
+//
+// Returns:
+//
+//	[]hpaEdge: abstract edges from start to goal, in order
+//	int: number of expanded abstract nodes
+//	bool: true if goal was reached
+func (h *HPAStar) searchAbstract(start, goal *Node, extra map[coordinate][]hpaEdge) ([]hpaEdge, int, bool) {
+	startCoord := coordinate{start.X, start.Y}
+	goalCoord := coordinate{goal.X, goal.Y}
+
+	g := map[coordinate]float64{startCoord: 0}
+	via := make(map[coordinate]hpaEdge)
+	from := make(map[coordinate]coordinate)
+	closed := make(map[coordinate]bool)
+
+	var open entryQueue
+	open.push(start, h.heuristic(start, goal), 0)
+	expanded := 0
+
+	for open.Len() > 0 {
+		entry := open.pop()
+		current := entry.node
+		coord := coordinate{current.X, current.Y}
+		if closed[coord] {
+			continue
+		}
+
+		if coord == goalCoord {
+			var edges []hpaEdge
+			for c := goalCoord; c != startCoord; c = from[c] {
+				edges = append(edges, via[c])
+			}
+			for i, j := 0, len(edges)-1; i < j; i, j = i+1, j-1 {
+				edges[i], edges[j] = edges[j], edges[i]
+			}
+			return edges, expanded, true
+		}
+
+		closed[coord] = true
+		expanded++
+
+		out := h.clusterOf(current.X, current.Y).edges[coord]
+		for _, list := range [][]hpaEdge{out, extra[coord]} {
+			for _, edge := range list {
+				next := coordinate{edge.to.X, edge.to.Y}
+				if closed[next] {
+					continue
+				}
+				newG := g[coord] + edge.cost
+				if old, seen := g[next]; seen && newG >= old {
+					continue
+				}
+				g[next] = newG
+				via[next] = edge
+				from[next] = coord
+				open.push(edge.to, newG+h.heuristic(edge.to, goal), -newG)
+			}
+		}
+	}
+
+	return nil, expanded, false
+}
+
+// setObstacle toggles a cell and rebuilds the affected clusters.
+func (h *HPAStar) setObstacle(x, y int, obstacle bool) error {
+	if h.grid == nil {
+		return fmt.Errorf("grid must be set before editing obstacles")
+	}
+
+	grid, ok := h.grid.(*Grid)
+	if !ok {
+		return fmt.Errorf("HPA* requires a *Grid, got %T", h.grid)
+	}
+
+	var err error
+	if obstacle {
+		err = grid.SetObstacle(x, y)
+	} else {
+		err = grid.ClearObstacle(x, y)
+	}
+	if err != nil {
+		return err
+	}
+
+	if h.built {
+		h.update(grid, x, y)
+	}
+	return nil
+}
+
+// update repairs the abstract graph after the cell (x, y) changed.
+// Transitions can only change between clusters that both contain a cell
+// next to (x, y), so only those pairs are recomputed. The cell's own
+// cluster, every cluster whose transitions changed and every cluster with a
+// transition into the cell get new edges; the last matters for cost edits,
+// since the cost of crossing into the cell depends on its Cost.
+func (h *HPAStar) update(grid *Grid, x, y int) {
+	nearby := make(map[int]*hpaCluster)
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if grid.IsValidPosition(x+dx, y+dy) {
+				c := h.clusterOf(x+dx, y+dy)
+				nearby[c.index] = c
+			}
+		}
+	}
+
+	home := h.clusterOf(x, y)
+	dirty := map[int]*hpaCluster{home.index: home}
+	for _, c := range nearby {
+		for _, n := range h.adjacentClusters(c) {
+			if c.index > n.index || nearby[n.index] == nil {
+				continue
+			}
+			key := hpaPair{c.index, n.index}
+			fresh := h.findTransitions(grid, c, n)
+			if !sameTransitions(h.transitions[key], fresh) {
+				h.transitions[key] = fresh
+				dirty[c.index] = c
+				dirty[n.index] = n
+			}
+		}
+	}
+
+	for _, n := range h.adjacentClusters(home) {
+		key := hpaPair{home.index, n.index}
+		if n.index < home.index {
+			key = hpaPair{n.index, home.index}
+		}
+		for _, t := range h.transitions[key] {
+			if (t.a.X == x && t.a.Y == y) || (t.b.X == x && t.b.Y == y) {
+				dirty[n.index] = n
+				break
+			}
+		}
+	}
+
+	for _, c := range dirty {
+		h.buildEdges(grid, c)
+	}
+}
+
+// findTransitions computes the transitions between clusters c and n.
+// Orthogonally adjacent cell pairs along the shared border are grouped into
+// entrances; each entrance gets a transition in its middle, or one at each
+// end if it is wide. Diagonal crossings only get their own transition when
+// both cells bridging them are blocked, since otherwise the crossing is
+// reachable through an orthogonal entrance.
+func (h *HPAStar) findTransitions(grid *Grid, c, n *hpaCluster) []hpaTransition {
+	var transitions, entrance []hpaTransition
+	flush := func() {
+		switch {
+		case len(entrance) == 0:
+		case len(entrance) >= hpaWideEntrance:
+			transitions = append(transitions, entrance[0], entrance[len(entrance)-1])
+		default:
+			transitions = append(transitions, entrance[len(entrance)/2])
+		}
+		entrance = entrance[:0]
+	}
+
+	// Only cells of c within one step of n can have a neighbor in n
+	for y := max(c.minY, n.minY-1); y <= min(c.maxY, n.maxY+1); y++ {
+		for x := max(c.minX, n.minX-1); x <= min(c.maxX, n.maxX+1); x++ {
+			a := grid.nodes[y][x]
+			if a.IsObstacle {
+				flush()
+				continue
+			}
+
+			orthogonal := false
+			for _, b := range grid.GetNeighbors(a) {
+				if !n.contains(b.X, b.Y) {
+					continue
+				}
+				if b.X == a.X || b.Y == a.Y {
+					orthogonal = true
+					entrance = append(entrance, hpaTransition{a, b})
+				} else if grid.IsObstacle(b.X, a.Y) && grid.IsObstacle(a.X, b.Y) {
+					transitions = append(transitions, hpaTransition{a, b})
+				}
+			}
+			if !orthogonal {
+				flush()
+			}
+		}
+	}
+	flush()
+
+	return transitions
+}
+
+// buildEdges recomputes the outgoing abstract edges of every entrance in c:
+// intra-cluster edges via AStar and inter-cluster edges across transitions.
+func (h *HPAStar) buildEdges(grid *Grid, c *hpaCluster) {
+	// Collect entrances and their inter-cluster edges
+	var entrances []*Node
+	inter := make(map[coordinate][]hpaEdge)
+	addEntrance := func(node *Node) {
+		coord := coordinate{node.X, node.Y}
+		if _, ok := inter[coord]; !ok {
+			inter[coord] = nil
+			entrances = append(entrances, node)
+		}
+	}
+
+	for _, n := range h.adjacentClusters(c) {
+		key, forward := hpaPair{c.index, n.index}, true
+		if n.index < c.index {
+			key, forward = hpaPair{n.index, c.index}, false
+		}
+		for _, t := range h.transitions[key] {
+			from, to := t.a, t.b
+			if !forward {
+				from, to = t.b, t.a
+			}
+			addEntrance(from)
+			coord := coordinate{from.X, from.Y}
+			inter[coord] = append(inter[coord], hpaEdge{
+				to:   to,
+				cost: grid.GetCost(from, to),
+				path: []*Node{from, to},
+			})
+		}
+	}
+
+	c.edges = make(map[coordinate][]hpaEdge, len(entrances))
+	for _, from := range entrances {
+		coord := coordinate{from.X, from.Y}
+		c.edges[coord] = inter[coord]
+		for _, to := range entrances {
+			if from == to {
+				continue
+			}
+			if edge, ok := h.localEdge(grid, c, from, to); ok {
+				c.edges[coord] = append(c.edges[coord], edge)
+			}
+		}
+	}
+}
+
+// localEdge computes the path between two nodes that stays inside cluster c.
+func (h *HPAStar) localEdge(grid *Grid, c *hpaCluster, from, to *Node) (hpaEdge, bool) {
+	h.local.SetGrid(&clusterView{grid: grid, cluster: c})
+	result, err := h.local.Search(from, to)
+	if err != nil {
+		return hpaEdge{}, false
+	}
+	return hpaEdge{to: to, cost: result.Cost, path: result.Path}, true
+}
+
+// clusterOf returns the cluster containing the cell (x, y).
+func (h *HPAStar) clusterOf(x, y int) *hpaCluster {
+	return h.clusters[(y/h.clusterSize)*h.cols+x/h.clusterSize]
+}
+
+// adjacentClusters returns the up to eight clusters surrounding c.
+func (h *HPAStar) adjacentClusters(c *hpaCluster) []*hpaCluster {
+	rows := len(h.clusters) / h.cols
+	cx, cy := c.index%h.cols, c.index/h.cols
+
+	adjacent := make([]*hpaCluster, 0, 8)
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			nx, ny := cx+dx, cy+dy
+			if (dx != 0 || dy != 0) && nx >= 0 && nx < h.cols && ny >= 0 && ny < rows {
+				adjacent = append(adjacent, h.clusters[ny*h.cols+nx])
+			}
+		}
+	}
+	return adjacent
+}
+
+// sameTransitions reports whether two transition lists are identical.
+func sameTransitions(a, b []hpaTransition) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,342 @@
+package algo
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// newRandomHPAGrid builds a grid with randomly placed obstacles and costs
+func newRandomHPAGrid(rng *rand.Rand, width, height int, movement MovementType) *Grid {
+	grid, _ := NewGrid(width, height, movement)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			node, _ := grid.GetNode(x, y)
+			if rng.Float64() < 0.25 {
+				node.IsObstacle = true
+			}
+			node.Cost = float64(1 + rng.Intn(3))
+		}
+	}
+	return grid
+}
+
+// TestHPAStar_ImplementsPathfinder verifies interface compliance
+func TestHPAStar_ImplementsPathfinder(t *testing.T) {
+	var _ Pathfinder = NewHPAStar()
+}
+
+// TestHPAStar_SetClusterSize tests cluster size validation
+func TestHPAStar_SetClusterSize(t *testing.T) {
+	h := NewHPAStar()
+	if err := h.SetClusterSize(0); err == nil {
+		t.Error("SetClusterSize(0) should return error")
+	}
+	if err := h.SetClusterSize(-3); err == nil {
+		t.Error("SetClusterSize(-3) should return error")
+	}
+	if err := h.SetClusterSize(8); err != nil {
+		t.Errorf("SetClusterSize(8) returned error: %v", err)
+	}
+}
+
+// TestHPAStar_FindPath_OpenGrid tests a long query across many clusters
+func TestHPAStar_FindPath_OpenGrid(t *testing.T) {
+	grid, _ := NewGrid(50, 50, FourWay)
+	h := NewHPAStar()
+	h.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(49, 49)
+
+	result, err := h.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search() returned error: %v", err)
+	}
+
+	assertContiguousPath(t, grid, result.Path)
+
+	// On an open FourWay grid every monotone path is optimal
+	if result.Cost != 98 {
+		t.Errorf("Expected cost 98, got %.3f", result.Cost)
+	}
+
+	if result.Optimal || !math.IsInf(result.Bound, 1) {
+		t.Errorf("HPA* should not claim optimality, got Optimal=%v Bound=%v", result.Optimal, result.Bound)
+	}
+
+	nodes, edges := h.AbstractGraphSize()
+	if nodes == 0 || edges == 0 {
+		t.Errorf("Expected a non-empty abstract graph, got %d nodes and %d edges", nodes, edges)
+	}
+}
+
+// TestHPAStar_FindPath_MatchesReachability tests that HPA* finds a path
+// exactly when one exists and stays close to the optimal cost
+func TestHPAStar_FindPath_MatchesReachability(t *testing.T) {
+	for _, movement := range []MovementType{FourWay, EightWay} {
+		rng := rand.New(rand.NewSource(7))
+		grid := newRandomHPAGrid(rng, 40, 30, movement)
+
+		h := NewHPAStar()
+		h.SetGrid(grid)
+		h.SetClusterSize(8)
+		dijkstra := NewDijkstra()
+		dijkstra.SetGrid(grid)
+
+		for i := 0; i < 30; i++ {
+			start, _ := grid.GetNode(rng.Intn(40), rng.Intn(30))
+			goal, _ := grid.GetNode(rng.Intn(40), rng.Intn(30))
+			if start.IsObstacle || goal.IsObstacle {
+				continue
+			}
+
+			optimalPath, optimalErr := dijkstra.FindPath(start, goal)
+			var optimal float64
+			if optimalErr == nil {
+				optimal = PathCost(grid, optimalPath)
+			}
+
+			result, err := h.Search(start, goal)
+			if (err == nil) != (optimalErr == nil) {
+				t.Fatalf("movement %d, (%d,%d)->(%d,%d): HPA* error %v, Dijkstra error %v",
+					movement, start.X, start.Y, goal.X, goal.Y, err, optimalErr)
+			}
+			if err != nil {
+				continue
+			}
+
+			assertContiguousPath(t, grid, result.Path)
+			if math.Abs(PathCost(grid, result.Path)-result.Cost) > 1e-9 {
+				t.Errorf("Reported cost %.3f does not match path cost %.3f", result.Cost, PathCost(grid, result.Path))
+			}
+			if result.Cost < optimal-1e-9 || result.Cost > 1.5*optimal+1e-9 {
+				t.Errorf("movement %d, (%d,%d)->(%d,%d): cost %.3f too far from optimal %.3f",
+					movement, start.X, start.Y, goal.X, goal.Y, result.Cost, optimal)
+			}
+		}
+	}
+}
+
+// TestHPAStar_FindPath_DiagonalOnlyCrossing tests clusters connected only
+// through a diagonal move between two blocked cells
+func TestHPAStar_FindPath_DiagonalOnlyCrossing(t *testing.T) {
+	grid, _ := NewGrid(8, 4, EightWay)
+	// Wall between the clusters at x=3|4 with no orthogonal crossing; the
+	// diagonal (3,1)->(4,2) squeezes between (4,1) and (3,2)
+	for y := 0; y < 4; y++ {
+		if y != 1 {
+			grid.SetObstacle(3, y)
+		}
+	}
+	grid.SetObstacle(4, 1)
+
+	h := NewHPAStar()
+	h.SetGrid(grid)
+	h.SetClusterSize(4)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(7, 3)
+
+	path, err := h.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+	assertContiguousPath(t, grid, path)
+}
+
+// TestHPAStar_SetObstacle_Reroutes tests that blocking and reopening an
+// entrance is reflected in subsequent queries
+func TestHPAStar_SetObstacle_Reroutes(t *testing.T) {
+	grid, _ := NewGrid(20, 10, FourWay)
+	// Vertical wall at x=10 with gaps at y=1 and y=8
+	for y := 0; y < 10; y++ {
+		if y != 1 && y != 8 {
+			grid.SetObstacle(10, y)
+		}
+	}
+
+	h := NewHPAStar()
+	h.SetGrid(grid)
+	h.SetClusterSize(5)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(19, 0)
+
+	path, err := h.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+	if !pathContains(path, 10, 1) {
+		t.Fatal("Expected path through the upper gap")
+	}
+
+	if err := h.SetObstacle(10, 1); err != nil {
+		t.Fatalf("SetObstacle() returned error: %v", err)
+	}
+	path, err = h.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath() after SetObstacle returned error: %v", err)
+	}
+	assertContiguousPath(t, grid, path)
+	if !pathContains(path, 10, 8) {
+		t.Error("Expected path through the lower gap after blocking the upper one")
+	}
+
+	h.SetObstacle(10, 8)
+	if _, err := h.FindPath(start, goal); err == nil {
+		t.Error("FindPath() should fail once both gaps are blocked")
+	}
+
+	if err := h.ClearObstacle(10, 1); err != nil {
+		t.Fatalf("ClearObstacle() returned error: %v", err)
+	}
+	path, err = h.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath() after ClearObstacle returned error: %v", err)
+	}
+	if !pathContains(path, 10, 1) {
+		t.Error("Expected path through the reopened upper gap")
+	}
+
+	if err := h.SetObstacle(20, 0); err == nil {
+		t.Error("SetObstacle() should return error for out-of-bounds position")
+	}
+}
+
+// TestHPAStar_IncrementalUpdate_MatchesRebuild tests that repeated edits
+// leave the same abstract graph as building from scratch, and that clusters
+// far from the edits are not rebuilt
+func TestHPAStar_IncrementalUpdate_MatchesRebuild(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	grid := newRandomHPAGrid(rng, 30, 30, EightWay)
+
+	h := NewHPAStar()
+	h.SetGrid(grid)
+	h.SetClusterSize(6)
+	if err := h.Build(); err != nil {
+		t.Fatalf("Build() returned error: %v", err)
+	}
+
+	// Remember the far corner cluster's edge map to check it is left alone
+	far := h.clusterOf(29, 29)
+	farEdges := reflect.ValueOf(far.edges).Pointer()
+
+	for i := 0; i < 60; i++ {
+		x, y := rng.Intn(18), rng.Intn(18)
+		if rng.Intn(2) == 0 {
+			h.SetObstacle(x, y)
+		} else {
+			h.ClearObstacle(x, y)
+		}
+	}
+
+	node, _ := grid.GetNode(3, 4)
+	node.Cost = 9
+	h.NotifyChanged(node)
+
+	if reflect.ValueOf(far.edges).Pointer() != farEdges {
+		t.Error("Cluster far from all edits was rebuilt")
+	}
+
+	fresh := NewHPAStar()
+	fresh.SetGrid(grid)
+	fresh.SetClusterSize(6)
+	fresh.Build()
+
+	if !reflect.DeepEqual(h.transitions, fresh.transitions) {
+		t.Fatal("Incremental transitions differ from a full rebuild")
+	}
+
+	for i, c := range h.clusters {
+		want := fresh.clusters[i].edges
+		if len(c.edges) != len(want) {
+			t.Fatalf("cluster %d: %d entrances, full rebuild has %d", i, len(c.edges), len(want))
+		}
+		for coord, out := range c.edges {
+			if len(out) != len(want[coord]) {
+				t.Fatalf("cluster %d entrance %v: %d edges, full rebuild has %d", i, coord, len(out), len(want[coord]))
+			}
+			for j, edge := range out {
+				if edge.to != want[coord][j].to || math.Abs(edge.cost-want[coord][j].cost) > 1e-9 {
+					t.Errorf("cluster %d entrance %v: edge %d differs from full rebuild", i, coord, j)
+				}
+			}
+		}
+	}
+}
+
+// TestHPAStar_NotifyChanged_BorderCost tests that a cost edit on a cluster
+// border updates the edge crossing into the cell from the neighboring cluster
+func TestHPAStar_NotifyChanged_BorderCost(t *testing.T) {
+	grid, _ := NewGrid(16, 1, FourWay)
+	h := NewHPAStar()
+	h.SetGrid(grid)
+	h.SetClusterSize(8)
+	if err := h.Build(); err != nil {
+		t.Fatalf("Build() returned error: %v", err)
+	}
+
+	// (8, 0) is the first cell of the second cluster
+	border, _ := grid.GetNode(8, 0)
+	border.Cost = 10
+	h.NotifyChanged(border)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(15, 0)
+	result, err := h.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search() returned error: %v", err)
+	}
+	if cost := PathCost(grid, result.Path); result.Cost != cost || cost != 24 {
+		t.Errorf("Expected reported and path cost 24, got %.1f and %.1f", result.Cost, cost)
+	}
+}
+
+// TestHPAStar_FindPath_InputValidation tests error handling for invalid inputs
+func TestHPAStar_FindPath_InputValidation(t *testing.T) {
+	h := NewHPAStar()
+	grid, _ := NewGrid(5, 5, FourWay)
+	node, _ := grid.GetNode(0, 0)
+	other, _ := grid.GetNode(4, 2)
+
+	if err := h.Build(); err == nil {
+		t.Error("Build() should return error when grid is not set")
+	}
+
+	if err := h.SetObstacle(1, 1); err == nil {
+		t.Error("SetObstacle() should return error when grid is not set")
+	}
+
+	if _, err := h.FindPath(nil, node); err == nil {
+		t.Error("FindPath() should return error for nil start node")
+	}
+
+	h.SetGrid(unsupportedGrid{grid})
+	if _, err := h.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error for grids other than *Grid")
+	}
+
+	h.SetGrid(grid)
+	h.SetHeuristic(nil)
+	if _, err := h.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when heuristic is nil")
+	}
+
+	h.SetHeuristic(Manhattan)
+	path, err := h.FindPath(node, node)
+	if err != nil || len(path) != 1 {
+		t.Errorf("Expected single-node path for same start/goal, got %v (err=%v)", path, err)
+	}
+}
+
+// pathContains reports whether the path visits the cell (x, y)
+func pathContains(path []*Node, x, y int) bool {
+	for _, node := range path {
+		if node.X == x && node.Y == y {
+			return true
+		}
+	}
+	return false
+}
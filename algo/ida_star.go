@@ -0,0 +1,227 @@
+package algo
+
+import (
+	"fmt"
+	"math"
+)
+
+// idaEpsilon absorbs floating-point rounding when comparing f-costs to the threshold.
+const idaEpsilon = 1e-9
+
+// IDAStar implements Iterative Deepening A* (IDA*).
+// IDA* performs a series of depth-first searches, each bounded by an f-cost
+// threshold. The first threshold is h(start); each subsequent threshold is
+// the smallest f-cost that exceeded the previous one. Only the current path
+// is kept in memory, so memory use is O(path length) instead of the open and
+// closed sets AStar needs, at the cost of re-expanding nodes across
+// iterations. With an admissible heuristic the returned path is optimal.
+//
+// An optional transposition table remembers the lowest g-cost at which each
+// node was reached during an iteration, pruning duplicate paths. Its size is
+// capped so memory stays bounded; once full, new nodes are not recorded.
+type IDAStar struct {
+	// grid is the search space we're pathfinding within
+	grid SearchSpace
+
+	// heuristic function estimates cost from current node to goal
+	heuristic HeuristicFunc
+
+	// maxTableSize caps the transposition table (0 = disabled)
+	maxTableSize int
+
+	// table maps coordinates to the best g-cost seen in the current iteration
+	table map[nodeKey]float64
+
+	// path is the current depth-first path from start
+	path []*Node
+
+	// onPath marks nodes on the current path to avoid cycles
+	onPath map[nodeKey]bool
+
+	// expanded counts node expansions across all iterations
+	expanded int
+}
+
+// NewIDAStar creates a new IDA* pathfinder instance.
+// By default, it uses the Manhattan heuristic and no transposition table.
+//
+// Returns:
+//
+//	*IDAStar: new IDA* pathfinder instance
+func NewIDAStar() *IDAStar {
+	return &IDAStar{
+		heuristic: Manhattan,
+	}
+}
+
+// SetGrid configures the grid/search space for the pathfinder.
+// This must be called before FindPath.
+//
+// Params:
+//
+//	grid: grid to search within
+func (ida *IDAStar) SetGrid(grid SearchSpace) {
+	ida.grid = grid
+}
+
+// SetHeuristic configures the heuristic function for the pathfinder.
+// The heuristic must be admissible for the returned path to be optimal.
+//
+// Params:
+//
+//	heuristic: heuristic function to use
+func (ida *IDAStar) SetHeuristic(heuristic HeuristicFunc) {
+	ida.heuristic = heuristic
+}
+
+// SetTranspositionTable enables a transposition table holding at most
+// maxEntries nodes. A table greatly reduces duplicate work on grids, which
+// contain many equal-cost paths to the same node. Pass 0 to disable it.
+//
+// Params:
+//
+//	maxEntries: maximum number of table entries (0 disables the table)
+//
+// Returns:
+//
+//	error: if maxEntries is negative
+func (ida *IDAStar) SetTranspositionTable(maxEntries int) error {
+	if maxEntries < 0 {
+		return fmt.Errorf("transposition table size must be >= 0, got %d", maxEntries)
+	}
+	ida.maxTableSize = maxEntries
+	return nil
+}
+
+// FindPath finds the optimal path from start to goal using IDA*.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	[]*Node: path from start to goal (including both endpoints)
+//	error: if no path exists or invalid input
+func (ida *IDAStar) FindPath(start, goal *Node) ([]*Node, error) {
+	result, err := ida.Search(start, goal)
+	if err != nil {
+		return nil, err
+	}
+	return result.Path, nil
+}
+
+// Search runs IDA* and returns a detailed result. NodesExpanded counts
+// expansions over all iterations, including repeated ones.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	*SearchResult: path, its cost and search statistics
+//	error: if no path exists or invalid input
+func (ida *IDAStar) Search(start, goal *Node) (*SearchResult, error) {
+	if err := validateEndpoints(ida.grid, start, goal); err != nil {
+		return nil, err
+	}
+
+	if ida.heuristic == nil {
+		return nil, fmt.Errorf("heuristic must be set before calling FindPath")
+	}
+
+	ida.path = []*Node{start}
+	ida.onPath = map[nodeKey]bool{keyOf(start): true}
+	ida.expanded = 0
+
+	threshold := ida.heuristic(start, goal)
+	for {
+		if ida.maxTableSize > 0 {
+			ida.table = make(map[nodeKey]float64)
+		}
+
+		cost, next, found := ida.search(start, goal, 0, threshold)
+		if found {
+			path := make([]*Node, len(ida.path))
+			copy(path, ida.path)
+			return &SearchResult{
+				Path:          path,
+				Cost:          cost,
+				NodesExpanded: ida.expanded,
+				Optimal:       true,
+				Bound:         1,
+			}, nil
+		}
+
+		// No node exceeded the threshold: the reachable space is exhausted
+		if math.IsInf(next, 1) {
+			return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+				start.X, start.Y, goal.X, goal.Y)
+		}
+		threshold = next
+	}
+}
+
+// search performs one threshold-bounded depth-first search from node.
+//
+// Params:
+//
+//	node: node at the end of the current path
+//	goal: destination node
+//	g: cost of the current path
+//	threshold: maximum f-cost explored in this iteration
+//
+// Returns:
+//
+//	float64: cost of the path to goal (if found)
+//	float64: smallest f-cost that exceeded the threshold (+Inf if none)
+//	bool: true if goal was reached
+func (ida *IDAStar) search(node, goal *Node, g, threshold float64) (float64, float64, bool) {
+	f := g + ida.heuristic(node, goal)
+	if f > threshold+idaEpsilon {
+		return 0, f, false
+	}
+
+	if node.Equals(goal) {
+		return g, f, true
+	}
+
+	if ida.table != nil {
+		coord := keyOf(node)
+		if best, seen := ida.table[coord]; seen && g >= best-idaEpsilon {
+			return 0, math.Inf(1), false
+		} else if seen || len(ida.table) < ida.maxTableSize {
+			ida.table[coord] = g
+		}
+	}
+
+	ida.expanded++
+	next := math.Inf(1)
+
+	for _, neighbor := range ida.grid.GetNeighbors(node) {
+		coord := keyOf(neighbor)
+		if neighbor.IsObstacle || ida.onPath[coord] {
+			continue
+		}
+
+		ida.path = append(ida.path, neighbor)
+		ida.onPath[coord] = true
+
+		cost, exceeded, found := ida.search(neighbor, goal, g+ida.grid.GetCost(node, neighbor), threshold)
+		if found {
+			return cost, exceeded, true
+		}
+
+		ida.path = ida.path[:len(ida.path)-1]
+		delete(ida.onPath, coord)
+
+		if exceeded < next {
+			next = exceeded
+		}
+	}
+
+	return 0, next, false
+}
@@ -0,0 +1,186 @@
+package algo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestIDAStar_ImplementsPathfinder verifies interface compliance
+func TestIDAStar_ImplementsPathfinder(t *testing.T) {
+	var _ Pathfinder = NewIDAStar()
+}
+
+// TestIDAStar_FindPath_SimpleCase tests basic pathfinding
+func TestIDAStar_FindPath_SimpleCase(t *testing.T) {
+	grid, _ := NewGrid(5, 5, FourWay)
+	ida := NewIDAStar()
+	ida.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(4, 4)
+
+	path, err := ida.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+
+	if len(path) != 9 {
+		t.Errorf("Expected path length 9, got %d", len(path))
+	}
+
+	assertContiguousPath(t, grid, path)
+}
+
+// TestIDAStar_MatchesAStar verifies optimal costs on weighted obstacle maps,
+// with and without a transposition table. Grids are kept small since plain
+// IDA* re-explores exponentially many equal-cost paths.
+func TestIDAStar_MatchesAStar(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+
+	for trial := 0; trial < 10; trial++ {
+		grid, _ := NewGrid(6, 6, FourWay)
+		for x := 0; x < 6; x++ {
+			for y := 0; y < 6; y++ {
+				node, _ := grid.GetNode(x, y)
+				node.Cost = 1 + float64(rng.Intn(3))
+				if rng.Float64() < 0.2 {
+					node.IsObstacle = true
+				}
+			}
+		}
+
+		start, _ := grid.GetNode(0, 0)
+		goal, _ := grid.GetNode(5, 5)
+		start.IsObstacle = false
+		goal.IsObstacle = false
+
+		astar := NewAStar()
+		astar.SetGrid(grid)
+		aResult, aErr := astar.Search(start, goal)
+
+		for _, tableSize := range []int{0, 16, 1000} {
+			ida := NewIDAStar()
+			ida.SetGrid(grid)
+			if err := ida.SetTranspositionTable(tableSize); err != nil {
+				t.Fatalf("SetTranspositionTable(%d) returned error: %v", tableSize, err)
+			}
+
+			result, err := ida.Search(start, goal)
+			if (aErr == nil) != (err == nil) {
+				t.Fatalf("trial %d (table=%d): A* err=%v, IDA* err=%v", trial, tableSize, aErr, err)
+			}
+			if err != nil {
+				continue
+			}
+
+			if math.Abs(result.Cost-aResult.Cost) > 1e-9 {
+				t.Errorf("trial %d (table=%d): IDA* cost %.2f differs from A* cost %.2f",
+					trial, tableSize, result.Cost, aResult.Cost)
+			}
+
+			if math.Abs(PathCost(grid, result.Path)-result.Cost) > 1e-9 {
+				t.Errorf("trial %d (table=%d): path cost %.2f does not match reported cost %.2f",
+					trial, tableSize, PathCost(grid, result.Path), result.Cost)
+			}
+
+			assertContiguousPath(t, grid, result.Path)
+
+			if len(ida.table) > tableSize {
+				t.Errorf("trial %d: transposition table grew to %d entries, cap is %d", trial, len(ida.table), tableSize)
+			}
+		}
+	}
+}
+
+// TestIDAStar_TranspositionTableReducesWork tests that the table prunes duplicates
+func TestIDAStar_TranspositionTableReducesWork(t *testing.T) {
+	// A wall forces several deepening iterations over many equal-cost paths
+	grid, _ := NewGrid(8, 8, FourWay)
+	for x := 1; x < 8; x++ {
+		grid.SetObstacle(x, 4)
+	}
+
+	start, _ := grid.GetNode(7, 0)
+	goal, _ := grid.GetNode(7, 7)
+
+	plain := NewIDAStar()
+	plain.SetGrid(grid)
+	plainResult, err := plain.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search() returned error: %v", err)
+	}
+
+	tabled := NewIDAStar()
+	tabled.SetGrid(grid)
+	tabled.SetTranspositionTable(64)
+	tabledResult, err := tabled.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search() with table returned error: %v", err)
+	}
+
+	if tabledResult.NodesExpanded >= plainResult.NodesExpanded {
+		t.Errorf("Table expanded %d nodes, expected fewer than %d without table",
+			tabledResult.NodesExpanded, plainResult.NodesExpanded)
+	}
+}
+
+// TestIDAStar_SetTranspositionTable tests size validation
+func TestIDAStar_SetTranspositionTable(t *testing.T) {
+	ida := NewIDAStar()
+
+	if err := ida.SetTranspositionTable(-1); err == nil {
+		t.Error("SetTranspositionTable() should reject negative sizes")
+	}
+
+	if err := ida.SetTranspositionTable(0); err != nil {
+		t.Errorf("SetTranspositionTable(0) returned error: %v", err)
+	}
+}
+
+// TestIDAStar_FindPath_NoPath tests when no path exists
+func TestIDAStar_FindPath_NoPath(t *testing.T) {
+	grid, _ := NewGrid(5, 5, FourWay)
+	for y := 0; y < 5; y++ {
+		grid.SetObstacle(2, y)
+	}
+
+	ida := NewIDAStar()
+	ida.SetGrid(grid)
+	ida.SetTranspositionTable(25)
+
+	start, _ := grid.GetNode(0, 2)
+	goal, _ := grid.GetNode(4, 2)
+
+	if path, err := ida.FindPath(start, goal); err == nil || path != nil {
+		t.Error("FindPath() should return nil path and error when no path exists")
+	}
+}
+
+// TestIDAStar_FindPath_InputValidation tests error handling for invalid inputs
+func TestIDAStar_FindPath_InputValidation(t *testing.T) {
+	ida := NewIDAStar()
+	grid, _ := NewGrid(5, 5, FourWay)
+	node, _ := grid.GetNode(0, 0)
+	other, _ := grid.GetNode(3, 1)
+
+	if _, err := ida.FindPath(node, nil); err == nil {
+		t.Error("FindPath() should return error for nil goal node")
+	}
+
+	if _, err := ida.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when grid is not set")
+	}
+
+	ida.SetGrid(grid)
+	ida.SetHeuristic(nil)
+	if _, err := ida.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when heuristic is nil")
+	}
+
+	ida.SetHeuristic(Manhattan)
+	path, err := ida.FindPath(node, node)
+	if err != nil || len(path) != 1 {
+		t.Errorf("Expected single-node path for same start/goal, got %v (err=%v)", path, err)
+	}
+}
@@ -0,0 +1,140 @@
+package algo
+
+import (
+	"math"
+)
+
+// dstarKey is the two-part priority used by D* Lite and LPA*, compared lexicographically.
+type dstarKey struct {
+	k1, k2 float64
+}
+
+// less reports whether key a sorts before key b.
+func (a dstarKey) less(b dstarKey) bool {
+	return a.k1 < b.k1 || (a.k1 == b.k1 && a.k2 < b.k2)
+}
+
+// incrementalState holds the search state shared by the incremental
+// planners D* Lite and LPA*: g and rhs values that survive between queries
+// and an open set of inconsistent nodes. The open set uses lazy deletion;
+// queued records the one valid key of each node, so stale entries are
+// skipped when they reach the top.
+type incrementalState struct {
+	// g and rhs hold the current and one-step-lookahead cost estimates
+	g, rhs map[nodeKey]float64
+
+	// openSet holds inconsistent nodes ordered by key (lazy deletion)
+	openSet entryQueue
+
+	// queued maps nodes currently in the open set to their valid key
+	queued map[nodeKey]dstarKey
+}
+
+// newIncrementalState creates an empty search state.
+func newIncrementalState() incrementalState {
+	return incrementalState{
+		g:      make(map[nodeKey]float64),
+		rhs:    make(map[nodeKey]float64),
+		queued: make(map[nodeKey]dstarKey),
+	}
+}
+
+// reset discards all g and rhs values and empties the open set.
+func (s *incrementalState) reset() {
+	s.g = make(map[nodeKey]float64)
+	s.rhs = make(map[nodeKey]float64)
+	s.queued = make(map[nodeKey]dstarKey)
+	s.openSet.clear()
+}
+
+// getG returns g(node), defaulting to +Inf for unvisited nodes.
+func (s *incrementalState) getG(node *Node) float64 {
+	if g, ok := s.g[keyOf(node)]; ok {
+		return g
+	}
+	return math.Inf(1)
+}
+
+// getRHS returns rhs(node), defaulting to +Inf for unvisited nodes.
+func (s *incrementalState) getRHS(node *Node) float64 {
+	if rhs, ok := s.rhs[keyOf(node)]; ok {
+		return rhs
+	}
+	return math.Inf(1)
+}
+
+// setG stores g(node).
+func (s *incrementalState) setG(node *Node, g float64) {
+	s.g[keyOf(node)] = g
+}
+
+// setRHS stores rhs(node).
+func (s *incrementalState) setRHS(node *Node, rhs float64) {
+	s.rhs[keyOf(node)] = rhs
+}
+
+// consistent reports whether g(node) equals rhs(node).
+func (s *incrementalState) consistent(node *Node) bool {
+	return s.getG(node) == s.getRHS(node)
+}
+
+// enqueue adds node to the open set with the given key, invalidating any
+// entry it already has.
+func (s *incrementalState) enqueue(node *Node, key dstarKey) {
+	s.queued[keyOf(node)] = key
+	s.openSet.push(node, key.k1, key.k2)
+}
+
+// requeue removes node from the open set and adds it back with key if it
+// is inconsistent.
+func (s *incrementalState) requeue(node *Node, key dstarKey) {
+	delete(s.queued, keyOf(node))
+	if !s.consistent(node) {
+		s.enqueue(node, key)
+	}
+}
+
+// topKey discards outdated queue entries and returns the smallest valid key.
+func (s *incrementalState) topKey() (dstarKey, bool) {
+	for s.openSet.Len() > 0 {
+		entry := s.openSet.entries[0]
+		key := dstarKey{entry.priority, entry.tieBreak}
+		if valid, ok := s.queued[keyOf(entry.node)]; ok && valid == key {
+			return key, true
+		}
+		s.openSet.pop()
+	}
+	return dstarKey{}, false
+}
+
+// pop removes the node with the smallest key from the open set and returns
+// it with that key. topKey must have returned true just before.
+func (s *incrementalState) pop() (*Node, dstarKey) {
+	entry := s.openSet.pop()
+	delete(s.queued, keyOf(entry.node))
+	return entry.node, dstarKey{entry.priority, entry.tieBreak}
+}
+
+// predecessors returns the nodes with a move into node: GetPredecessors if
+// the space provides it, otherwise GetNeighbors, which is correct whenever
+// moves are symmetric as on grids.
+func predecessors(space SearchSpace, node *Node) []*Node {
+	if space, ok := space.(predecessorSpace); ok {
+		return space.GetPredecessors(node)
+	}
+	return space.GetNeighbors(node)
+}
+
+// cheapest returns the node through which the cost is lowest, minimizing
+// cost(node) + g(node), together with that cost. It returns nil and +Inf if
+// no node has a finite cost.
+func (s *incrementalState) cheapest(nodes []*Node, cost func(*Node) float64) (*Node, float64) {
+	var best *Node
+	bestCost := math.Inf(1)
+	for _, node := range nodes {
+		if c := cost(node) + s.getG(node); c < bestCost {
+			best, bestCost = node, c
+		}
+	}
+	return best, bestCost
+}
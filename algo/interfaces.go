@@ -38,25 +38,19 @@ type Pathfinder interface {
 	SetHeuristic(heuristic HeuristicFunc)
 
 	// SetGrid configures the grid/search space for the pathfinder.
+	// Pathfinders that need more than SearchSpace (such as JPS, which
+	// requires a *Grid) report unsupported spaces from FindPath.
 	//
 	// Params:
-	//   grid: grid to search within
-	SetGrid(grid GridInterface)
+	//   grid: grid or graph to search within
+	SetGrid(grid SearchSpace)
 }
 
-// GridInterface represents a searchable space for pathfinding algorithms.
-// This interface abstracts the underlying representation (2D grid, graph, etc.)
-// and provides the necessary operations for pathfinding.
-type GridInterface interface {
-	// GetNode returns the node at the specified coordinates.
-	//
-	// Params:
-	//   x, y: coordinates
-	// Returns:
-	//   *Node: node at the position
-	//   error: if position is invalid
-	GetNode(x, y int) (*Node, error)
-
+// SearchSpace represents anything a pathfinder can search: a set of nodes
+// connected by weighted moves. Grids and graphs both implement it.
+// Implementations must hand out the same *Node for the same location every
+// time, since pathfinders store search state on the nodes.
+type SearchSpace interface {
 	// GetNeighbors returns all valid neighbors of a node.
 	// Neighbors should exclude obstacles and out-of-bounds positions.
 	//
@@ -66,14 +60,6 @@ type GridInterface interface {
 	//   []*Node: slice of neighboring nodes
 	GetNeighbors(node *Node) []*Node
 
-	// IsObstacle checks if a position is an obstacle or out of bounds.
-	//
-	// Params:
-	//   x, y: coordinates to check
-	// Returns:
-	//   bool: true if position is blocked
-	IsObstacle(x, y int) bool
-
 	// GetCost returns the movement cost from one node to another.
 	// This supports weighted grids and different terrain types.
 	//
@@ -87,3 +73,27 @@ type GridInterface interface {
 	// This allows reusing the grid for multiple pathfinding operations.
 	Reset()
 }
+
+// GridInterface represents a search space whose nodes are addressed by
+// (x, y) coordinates, such as Grid and HexGrid. Coordinate-based tools
+// like flow fields and multi-agent solvers require it.
+type GridInterface interface {
+	SearchSpace
+
+	// GetNode returns the node at the specified coordinates.
+	//
+	// Params:
+	//   x, y: coordinates
+	// Returns:
+	//   *Node: node at the position
+	//   error: if position is invalid
+	GetNode(x, y int) (*Node, error)
+
+	// IsObstacle checks if a position is an obstacle or out of bounds.
+	//
+	// Params:
+	//   x, y: coordinates to check
+	// Returns:
+	//   bool: true if position is blocked
+	IsObstacle(x, y int) bool
+}
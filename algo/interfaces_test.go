@@ -43,6 +43,26 @@ func TestGridInterfaceCompliance(t *testing.T) {
 	gridInterface.Reset()
 }
 
+// Test that every search space implements SearchSpace, and that only the
+// spaces addressed by (x, y) alone implement GridInterface
+func TestSearchSpaceCompliance(t *testing.T) {
+	grid, _ := NewGrid(3, 3, FourWay)
+	hex, _ := NewHexGrid(3, 3, PointyTop)
+	voxels, _ := NewGrid3D(3, 3, 3, Connect6)
+
+	for _, space := range []SearchSpace{grid, hex} {
+		if _, ok := space.(GridInterface); !ok {
+			t.Errorf("%T should implement GridInterface", space)
+		}
+	}
+
+	for _, space := range []SearchSpace{voxels, NewGraph()} {
+		if _, ok := space.(GridInterface); ok {
+			t.Errorf("%T should not implement GridInterface", space)
+		}
+	}
+}
+
 // Test heuristic function type
 func TestHeuristicFunc(t *testing.T) {
 	// Create a simple Manhattan distance heuristic for testing
@@ -0,0 +1,349 @@
+package algo
+
+import (
+	"fmt"
+)
+
+// JPS implements Jump Point Search, an optimization of A* for uniform-cost
+// grids with 8-way movement. Instead of adding every neighbor to the open
+// set, JPS prunes neighbors that are reachable at equal cost through other
+// routes (path symmetry) and "jumps" along straight and diagonal lines until
+// it reaches a node with a forced neighbor or the goal. Only these jump
+// points are expanded, which returns the same optimal cost as AStar with the
+// DiagonalWithCost heuristic while expanding far fewer nodes.
+//
+// JPS requires a *Grid with EightWay movement, the DiagonalAlways policy
+// and Cost == 1.0 on every walkable node; FindPath returns an error for any
+// other search space. Its pruning rules assume that diagonal moves may
+// pass between two orthogonal obstacles.
+type JPS struct {
+	// grid is the search space we're pathfinding within
+	grid SearchSpace
+
+	// heuristic function estimates cost from current node to goal
+	heuristic HeuristicFunc
+
+	// openSet contains jump points to be evaluated, ordered by f-cost
+	openSet *PriorityQueue
+
+	// closedSet contains jump points already evaluated (coordinates -> bool)
+	closedSet map[coordinate]bool
+}
+
+// NewJPS creates a new Jump Point Search pathfinder instance.
+// By default, it uses the DiagonalWithCost heuristic, which matches the
+// octile movement costs of an EightWay Grid.
+//
+// Returns:
+//
+//	*JPS: new JPS pathfinder instance
+func NewJPS() *JPS {
+	return &JPS{
+		heuristic: DiagonalWithCost,
+		openSet:   NewPriorityQueue(),
+		closedSet: make(map[coordinate]bool),
+	}
+}
+
+// SetGrid configures the grid/search space for the pathfinder.
+// JPS only supports *Grid with EightWay movement; this is checked in FindPath.
+//
+// Params:
+//
+//	grid: grid to search within
+func (j *JPS) SetGrid(grid SearchSpace) {
+	j.grid = grid
+}
+
+// SetHeuristic configures the heuristic function for the pathfinder.
+// For optimal results it must be admissible for octile movement costs
+// (DiagonalWithCost, Diagonal or Euclidean).
+//
+// Params:
+//
+//	heuristic: heuristic function to use
+func (j *JPS) SetHeuristic(heuristic HeuristicFunc) {
+	j.heuristic = heuristic
+}
+
+// FindPath finds the optimal path from start to goal using Jump Point Search.
+// The returned path contains every intermediate node, not only jump points,
+// so it can be used exactly like a path returned by AStar.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	[]*Node: path from start to goal (including both endpoints)
+//	error: if no path exists, the grid is unsupported, or invalid input
+func (j *JPS) FindPath(start, goal *Node) ([]*Node, error) {
+	result, err := j.Search(start, goal)
+	if err != nil {
+		return nil, err
+	}
+	return result.Path, nil
+}
+
+// Search runs Jump Point Search and returns a detailed result.
+// NodesExpanded counts expanded jump points.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	*SearchResult: path, its cost and search statistics
+//	error: if no path exists, the grid is unsupported, or invalid input
+func (j *JPS) Search(start, goal *Node) (*SearchResult, error) {
+	if err := validateEndpoints(j.grid, start, goal); err != nil {
+		return nil, err
+	}
+
+	if j.heuristic == nil {
+		return nil, fmt.Errorf("heuristic must be set before calling FindPath")
+	}
+
+	grid, ok := j.grid.(*Grid)
+	if !ok {
+		return nil, fmt.Errorf("JPS requires a *Grid search space, got %T", j.grid)
+	}
+
+	if grid.MovementType != EightWay {
+		return nil, fmt.Errorf("JPS requires EightWay movement, got %s", grid.movementTypeString())
+	}
+
+	if grid.DiagonalPolicy != DiagonalAlways {
+		return nil, fmt.Errorf("JPS requires the DiagonalAlways policy, got %s", grid.diagonalPolicyString())
+	}
+
+	if !grid.HasUniformCost() {
+		return nil, fmt.Errorf("JPS requires uniform movement costs; grid has weighted nodes")
+	}
+
+	if start.Equals(goal) {
+		return &SearchResult{Path: []*Node{start}, Optimal: true, Bound: 1}, nil
+	}
+
+	j.reset()
+
+	start.G = 0
+	start.H = j.heuristic(start, goal)
+	start.CalculateF()
+	start.Parent = nil
+	j.openSet.PushNode(start)
+
+	expanded := 0
+	for !j.openSet.IsEmpty() {
+		current := j.openSet.PopNode()
+
+		if current.Equals(goal) {
+			return &SearchResult{
+				Path:          j.expandPath(grid, reconstructPath(current)),
+				Cost:          current.G,
+				NodesExpanded: expanded,
+				Optimal:       true,
+				Bound:         1,
+			}, nil
+		}
+
+		j.closedSet[coordinate{current.X, current.Y}] = true
+		expanded++
+
+		for _, dir := range j.prunedDirections(grid, current) {
+			jumpPoint := j.jump(grid, current.X, current.Y, dir[0], dir[1], goal)
+			if jumpPoint == nil || j.closedSet[coordinate{jumpPoint.X, jumpPoint.Y}] {
+				continue
+			}
+
+			newG := current.G + j.segmentCost(grid, current, jumpPoint)
+			inOpenSet := j.openSet.ContainsNode(jumpPoint)
+
+			if !inOpenSet || newG < jumpPoint.G {
+				jumpPoint.G = newG
+				jumpPoint.H = j.heuristic(jumpPoint, goal)
+				jumpPoint.CalculateF()
+				jumpPoint.Parent = current
+
+				if !inOpenSet {
+					j.openSet.PushNode(jumpPoint)
+				} else {
+					j.openSet.UpdatePriority(jumpPoint.X, jumpPoint.Y, jumpPoint.F)
+				}
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+		start.X, start.Y, goal.X, goal.Y)
+}
+
+// prunedDirections returns the directions worth jumping in from a node,
+// based on the direction it was reached from. The start node (no parent)
+// searches in all 8 directions.
+//
+// Params:
+//
+//	grid: grid being searched
+//	node: node being expanded
+//
+// Returns:
+//
+//	[][2]int: [dx, dy] directions of natural and forced neighbors
+func (j *JPS) prunedDirections(grid *Grid, node *Node) [][2]int {
+	if node.Parent == nil {
+		return grid.getDirections()
+	}
+
+	x, y := node.X, node.Y
+	dx := sign(x - node.Parent.X)
+	dy := sign(y - node.Parent.Y)
+	walkable := func(x, y int) bool { return !grid.IsObstacle(x, y) }
+
+	dirs := make([][2]int, 0, 5)
+	add := func(ddx, ddy int) {
+		if walkable(x+ddx, y+ddy) {
+			dirs = append(dirs, [2]int{ddx, ddy})
+		}
+	}
+
+	switch {
+	case dx != 0 && dy != 0:
+		// Natural neighbors: both straight components and the diagonal
+		add(0, dy)
+		add(dx, 0)
+		add(dx, dy)
+		// Forced neighbors around obstacles behind the diagonal
+		if !walkable(x-dx, y) {
+			add(-dx, dy)
+		}
+		if !walkable(x, y-dy) {
+			add(dx, -dy)
+		}
+	case dx != 0:
+		add(dx, 0)
+		if !walkable(x, y+1) {
+			add(dx, 1)
+		}
+		if !walkable(x, y-1) {
+			add(dx, -1)
+		}
+	default:
+		add(0, dy)
+		if !walkable(x+1, y) {
+			add(1, dy)
+		}
+		if !walkable(x-1, y) {
+			add(-1, dy)
+		}
+	}
+
+	return dirs
+}
+
+// jump moves from (x, y) in direction (dx, dy) until it finds a jump point:
+// the goal, a node with a forced neighbor, or (for diagonals) a node from
+// which a straight jump finds a jump point. Returns nil if it hits an
+// obstacle or the grid boundary first.
+//
+// Params:
+//
+//	grid: grid being searched
+//	x, y: position to jump from
+//	dx, dy: direction of travel
+//	goal: goal node
+//
+// Returns:
+//
+//	*Node: jump point, or nil if none exists in this direction
+func (j *JPS) jump(grid *Grid, x, y, dx, dy int, goal *Node) *Node {
+	walkable := func(x, y int) bool { return !grid.IsObstacle(x, y) }
+
+	for {
+		x += dx
+		y += dy
+
+		if !walkable(x, y) {
+			return nil
+		}
+
+		node := grid.nodes[y][x]
+		if node.Equals(goal) {
+			return node
+		}
+
+		switch {
+		case dx != 0 && dy != 0:
+			if (walkable(x-dx, y+dy) && !walkable(x-dx, y)) ||
+				(walkable(x+dx, y-dy) && !walkable(x, y-dy)) {
+				return node
+			}
+			// A diagonal move stops where a straight jump finds something
+			if j.jump(grid, x, y, dx, 0, goal) != nil || j.jump(grid, x, y, 0, dy, goal) != nil {
+				return node
+			}
+		case dx != 0:
+			if (walkable(x+dx, y+1) && !walkable(x, y+1)) ||
+				(walkable(x+dx, y-1) && !walkable(x, y-1)) {
+				return node
+			}
+		default:
+			if (walkable(x+1, y+dy) && !walkable(x+1, y)) ||
+				(walkable(x-1, y+dy) && !walkable(x-1, y)) {
+				return node
+			}
+		}
+	}
+}
+
+// segmentCost returns the movement cost between two jump points that lie on
+// a single straight or diagonal line.
+func (j *JPS) segmentCost(grid *Grid, from, to *Node) float64 {
+	steps := abs(to.X - from.X)
+	if dy := abs(to.Y - from.Y); dy > steps {
+		steps = dy
+	}
+
+	next := grid.nodes[from.Y+sign(to.Y-from.Y)][from.X+sign(to.X-from.X)]
+	return float64(steps) * grid.GetCost(from, next)
+}
+
+// expandPath fills in the intermediate nodes between consecutive jump points.
+//
+// Params:
+//
+//	grid: grid being searched
+//	jumpPoints: path of jump points from start to goal
+//
+// Returns:
+//
+//	[]*Node: path containing every node from start to goal
+func (j *JPS) expandPath(grid *Grid, jumpPoints []*Node) []*Node {
+	path := []*Node{jumpPoints[0]}
+
+	for i := 1; i < len(jumpPoints); i++ {
+		from, to := jumpPoints[i-1], jumpPoints[i]
+		dx, dy := sign(to.X-from.X), sign(to.Y-from.Y)
+
+		for x, y := from.X, from.Y; x != to.X || y != to.Y; {
+			x += dx
+			y += dy
+			path = append(path, grid.nodes[y][x])
+		}
+	}
+
+	return path
+}
+
+// reset clears the algorithm state for a fresh pathfinding operation.
+func (j *JPS) reset() {
+	j.openSet.Clear()
+	j.closedSet = make(map[coordinate]bool)
+	if j.grid != nil {
+		j.grid.Reset()
+	}
+}
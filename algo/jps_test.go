@@ -0,0 +1,181 @@
+package algo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestJPS_ImplementsPathfinder verifies interface compliance
+func TestJPS_ImplementsPathfinder(t *testing.T) {
+	var _ Pathfinder = NewJPS()
+}
+
+// TestJPS_FindPath_OpenGrid tests a straight diagonal on an empty grid
+func TestJPS_FindPath_OpenGrid(t *testing.T) {
+	grid, _ := NewGrid(10, 10, EightWay)
+	jps := NewJPS()
+	jps.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(9, 9)
+
+	result, err := jps.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search() returned error: %v", err)
+	}
+
+	if len(result.Path) != 10 {
+		t.Errorf("Expected path length 10, got %d", len(result.Path))
+	}
+
+	if math.Abs(result.Cost-9*1.414) > 1e-9 {
+		t.Errorf("Expected cost %.3f, got %.3f", 9*1.414, result.Cost)
+	}
+
+	assertContiguousPath(t, grid, result.Path)
+}
+
+// TestJPS_MatchesAStar verifies JPS finds the same optimal cost as A* with
+// DiagonalWithCost on random obstacle maps, while expanding fewer nodes
+func TestJPS_MatchesAStar(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+
+	totalJPS, totalAStar := 0, 0
+	for trial := 0; trial < 30; trial++ {
+		grid, _ := NewGrid(40, 40, EightWay)
+		for x := 0; x < 40; x++ {
+			for y := 0; y < 40; y++ {
+				if rng.Float64() < 0.25 {
+					grid.SetObstacle(x, y)
+				}
+			}
+		}
+
+		start, _ := grid.GetNode(rng.Intn(40), rng.Intn(40))
+		goal, _ := grid.GetNode(rng.Intn(40), rng.Intn(40))
+		start.IsObstacle = false
+		goal.IsObstacle = false
+
+		astar := NewAStar()
+		astar.SetGrid(grid)
+		astar.SetHeuristic(DiagonalWithCost)
+		aResult, aErr := astar.Search(start, goal)
+
+		jps := NewJPS()
+		jps.SetGrid(grid)
+		jResult, jErr := jps.Search(start, goal)
+
+		if (aErr == nil) != (jErr == nil) {
+			t.Fatalf("trial %d: A* err=%v, JPS err=%v", trial, aErr, jErr)
+		}
+		if aErr != nil {
+			continue
+		}
+
+		if math.Abs(aResult.Cost-jResult.Cost) > 1e-9 {
+			t.Errorf("trial %d: JPS cost %.4f differs from A* cost %.4f", trial, jResult.Cost, aResult.Cost)
+		}
+
+		if math.Abs(PathCost(grid, jResult.Path)-jResult.Cost) > 1e-9 {
+			t.Errorf("trial %d: expanded path cost %.4f does not match reported cost %.4f",
+				trial, PathCost(grid, jResult.Path), jResult.Cost)
+		}
+
+		assertContiguousPath(t, grid, jResult.Path)
+		totalJPS += jResult.NodesExpanded
+		totalAStar += aResult.NodesExpanded
+	}
+
+	if totalJPS >= totalAStar {
+		t.Errorf("JPS expanded %d nodes, expected fewer than A*'s %d", totalJPS, totalAStar)
+	}
+}
+
+// TestJPS_RejectsUnsupportedGrids tests the grid preconditions
+func TestJPS_RejectsUnsupportedGrids(t *testing.T) {
+	t.Run("four-way movement", func(t *testing.T) {
+		grid, _ := NewGrid(5, 5, FourWay)
+		jps := NewJPS()
+		jps.SetGrid(grid)
+		start, _ := grid.GetNode(0, 0)
+		goal, _ := grid.GetNode(4, 4)
+
+		if _, err := jps.FindPath(start, goal); err == nil {
+			t.Error("FindPath() should reject FourWay grids")
+		}
+	})
+
+	t.Run("weighted nodes", func(t *testing.T) {
+		grid, _ := NewGrid(5, 5, EightWay)
+		node, _ := grid.GetNode(2, 2)
+		node.Cost = 2.0
+		jps := NewJPS()
+		jps.SetGrid(grid)
+		start, _ := grid.GetNode(0, 0)
+		goal, _ := grid.GetNode(4, 4)
+
+		if _, err := jps.FindPath(start, goal); err == nil {
+			t.Error("FindPath() should reject grids with non-uniform Node.Cost")
+		}
+	})
+
+	t.Run("restricted diagonals", func(t *testing.T) {
+		grid, _ := NewGrid(5, 5, EightWay)
+		grid.DiagonalPolicy = DiagonalIfNoObstacles
+		jps := NewJPS()
+		jps.SetGrid(grid)
+		start, _ := grid.GetNode(0, 0)
+		goal, _ := grid.GetNode(4, 4)
+
+		if _, err := jps.FindPath(start, goal); err == nil {
+			t.Error("FindPath() should reject grids with a restricted DiagonalPolicy")
+		}
+	})
+}
+
+// TestJPS_FindPath_NoPath tests when no path exists
+func TestJPS_FindPath_NoPath(t *testing.T) {
+	grid, _ := NewGrid(5, 5, EightWay)
+	for y := 0; y < 5; y++ {
+		grid.SetObstacle(2, y)
+	}
+
+	jps := NewJPS()
+	jps.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 2)
+	goal, _ := grid.GetNode(4, 2)
+
+	if path, err := jps.FindPath(start, goal); err == nil || path != nil {
+		t.Error("FindPath() should return nil path and error when no path exists")
+	}
+}
+
+// TestJPS_FindPath_InputValidation tests error handling for invalid inputs
+func TestJPS_FindPath_InputValidation(t *testing.T) {
+	jps := NewJPS()
+	grid, _ := NewGrid(5, 5, EightWay)
+	node, _ := grid.GetNode(0, 0)
+	other, _ := grid.GetNode(3, 1)
+
+	if _, err := jps.FindPath(nil, node); err == nil {
+		t.Error("FindPath() should return error for nil start node")
+	}
+
+	if _, err := jps.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when grid is not set")
+	}
+
+	jps.SetGrid(grid)
+	jps.SetHeuristic(nil)
+	if _, err := jps.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when heuristic is nil")
+	}
+
+	jps.SetHeuristic(Diagonal)
+	path, err := jps.FindPath(node, node)
+	if err != nil || len(path) != 1 {
+		t.Errorf("Expected single-node path for same start/goal, got %v (err=%v)", path, err)
+	}
+}
@@ -0,0 +1,374 @@
+package algo
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RankedPath is one of the alternative paths returned by KShortestPaths.
+type RankedPath struct {
+	// Path is the node path from start to goal (including both endpoints)
+	Path []*Node
+
+	// Cost is the total movement cost of Path
+	Cost float64
+}
+
+// edgeKey identifies a directed edge between two cells.
+type edgeKey struct {
+	from, to nodeKey
+}
+
+// KShortest finds alternative routes with Yen's algorithm (Yen, 1971).
+// The first path is the optimal one; each following path is the cheapest
+// loopless path that differs from all earlier ones. Candidates are built by
+// taking a prefix ("root") of an earlier path and searching a new "spur"
+// path from its last node while the next edges of all earlier paths sharing
+// that root are blocked.
+//
+// With a minimum dissimilarity set, paths too similar to an already
+// returned path are skipped, so the results are genuinely different routes.
+// On grids, many near-identical paths exist; the search gives up after
+// examining a limited number of candidates (see SetMaxCandidates).
+type KShortest struct {
+	// grid is the search space we're pathfinding within
+	grid SearchSpace
+
+	// heuristic function estimates cost from current node to goal
+	heuristic HeuristicFunc
+
+	// minDissimilarity is the minimum dissimilarity to every earlier result (0-1)
+	minDissimilarity float64
+
+	// maxCandidates caps the number of paths examined per query
+	maxCandidates int
+}
+
+// NewKShortest creates a new k-shortest-paths finder.
+// By default, it uses the Manhattan heuristic, no dissimilarity requirement
+// and examines at most 1000 candidate paths per query.
+//
+// Returns:
+//
+//	*KShortest: new k-shortest-paths finder
+func NewKShortest() *KShortest {
+	return &KShortest{
+		heuristic:     Manhattan,
+		maxCandidates: 1000,
+	}
+}
+
+// SetGrid configures the grid/search space.
+//
+// Params:
+//
+//	grid: grid to search within
+func (ks *KShortest) SetGrid(grid SearchSpace) {
+	ks.grid = grid
+}
+
+// SetHeuristic configures the heuristic used by the underlying searches.
+// The heuristic must be admissible for paths to be returned in cost order.
+//
+// Params:
+//
+//	heuristic: heuristic function to use
+func (ks *KShortest) SetHeuristic(heuristic HeuristicFunc) {
+	ks.heuristic = heuristic
+}
+
+// SetMinDissimilarity requires every returned path to differ from all
+// earlier ones by at least d. Dissimilarity is 1 minus the cost of the
+// edges two paths share divided by the cost of the cheaper path, so 0
+// accepts any distinct path and 1 requires fully edge-disjoint paths.
+//
+// Params:
+//
+//	d: minimum dissimilarity in [0, 1]
+//
+// Returns:
+//
+//	error: if d is outside [0, 1]
+func (ks *KShortest) SetMinDissimilarity(d float64) error {
+	if math.IsNaN(d) || d < 0 || d > 1 {
+		return fmt.Errorf("minimum dissimilarity must be in [0, 1], got %v", d)
+	}
+	ks.minDissimilarity = d
+	return nil
+}
+
+// SetMaxCandidates caps how many candidate paths a query examines,
+// including those rejected as too similar.
+//
+// Params:
+//
+//	n: maximum number of candidates (must be > 0)
+//
+// Returns:
+//
+//	error: if n is not positive
+func (ks *KShortest) SetMaxCandidates(n int) error {
+	if n <= 0 {
+		return fmt.Errorf("maximum candidates must be > 0, got %d", n)
+	}
+	ks.maxCandidates = n
+	return nil
+}
+
+// FindPath returns the shortest path, making KShortest usable as a Pathfinder.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	[]*Node: path from start to goal (including both endpoints)
+//	error: if no path exists or invalid input
+func (ks *KShortest) FindPath(start, goal *Node) ([]*Node, error) {
+	paths, err := ks.KShortestPaths(start, goal, 1)
+	if err != nil {
+		return nil, err
+	}
+	return paths[0].Path, nil
+}
+
+// KShortestPaths returns up to k loopless paths from start to goal in
+// ascending cost order. Fewer than k paths are returned when no more
+// (sufficiently dissimilar) paths exist or the candidate limit is reached.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//	k: maximum number of paths (must be > 0)
+//
+// Returns:
+//
+//	[]RankedPath: paths with their costs, cheapest first
+//	error: if no path exists or invalid input
+func (ks *KShortest) KShortestPaths(start, goal *Node, k int) ([]RankedPath, error) {
+	if err := validateEndpoints(ks.grid, start, goal); err != nil {
+		return nil, err
+	}
+
+	if ks.heuristic == nil {
+		return nil, fmt.Errorf("heuristic must be set before calling FindPath")
+	}
+
+	if k <= 0 {
+		return nil, fmt.Errorf("k must be > 0, got %d", k)
+	}
+
+	first, ok := ks.spurSearch(start, goal, nil, nil)
+	if !ok {
+		return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+			start.X, start.Y, goal.X, goal.Y)
+	}
+
+	// extracted holds every path taken from the candidate heap (used for
+	// blocking edges); accepted is the subset returned to the caller
+	extracted := []RankedPath{first}
+	accepted := []RankedPath{first}
+	candidates := &rankedPathHeap{}
+	seen := map[string]bool{pathKey(first.Path): true}
+
+	for len(accepted) < k && len(extracted) < ks.maxCandidates {
+		ks.addSpurCandidates(extracted, goal, candidates, seen)
+
+		if candidates.Len() == 0 {
+			break
+		}
+
+		next := heap.Pop(candidates).(RankedPath)
+		extracted = append(extracted, next)
+		if ks.isDissimilar(next, accepted) {
+			accepted = append(accepted, next)
+		}
+	}
+
+	return accepted, nil
+}
+
+// addSpurCandidates generates Yen's candidates from the latest extracted
+// path: for every prefix, the cheapest continuation that avoids the prefix
+// nodes and the next edge of every extracted path sharing that prefix.
+func (ks *KShortest) addSpurCandidates(extracted []RankedPath, goal *Node, candidates *rankedPathHeap, seen map[string]bool) {
+	last := extracted[len(extracted)-1].Path
+	rootCost := 0.0
+
+	for i := 0; i < len(last)-1; i++ {
+		spur := last[i]
+		root := last[:i+1]
+		if i > 0 {
+			rootCost += ks.grid.GetCost(last[i-1], spur)
+		}
+
+		blockedEdges := make(map[edgeKey]bool)
+		for _, p := range extracted {
+			if len(p.Path) > i+1 && sharesPrefix(p.Path, root) {
+				blockedEdges[edgeKey{keyOf(p.Path[i]), keyOf(p.Path[i+1])}] = true
+			}
+		}
+
+		blockedNodes := make(map[nodeKey]bool, i)
+		for _, node := range root[:i] {
+			blockedNodes[keyOf(node)] = true
+		}
+
+		spurPath, ok := ks.spurSearch(spur, goal, blockedNodes, blockedEdges)
+		if !ok {
+			continue
+		}
+
+		path := make([]*Node, 0, i+len(spurPath.Path))
+		path = append(path, root[:i]...)
+		path = append(path, spurPath.Path...)
+
+		key := pathKey(path)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		heap.Push(candidates, RankedPath{Path: path, Cost: rootCost + spurPath.Cost})
+	}
+}
+
+// spurSearch runs A* from start to goal, avoiding blocked nodes and edges.
+// Search state is kept in local maps so node fields are left untouched.
+func (ks *KShortest) spurSearch(start, goal *Node, blockedNodes map[nodeKey]bool, blockedEdges map[edgeKey]bool) (RankedPath, bool) {
+	startCoord := keyOf(start)
+	g := map[nodeKey]float64{startCoord: 0}
+	parent := make(map[nodeKey]*Node)
+	closed := make(map[nodeKey]bool)
+
+	var open entryQueue
+	open.push(start, ks.heuristic(start, goal), 0)
+
+	for open.Len() > 0 {
+		current := open.pop().node
+		coord := keyOf(current)
+		if closed[coord] {
+			continue
+		}
+
+		if current.Equals(goal) {
+			path := []*Node{current}
+			for c := coord; c != startCoord; {
+				p := parent[c]
+				path = append(path, p)
+				c = keyOf(p)
+			}
+			reversePath(path)
+			return RankedPath{Path: path, Cost: g[coord]}, true
+		}
+
+		closed[coord] = true
+		for _, neighbor := range ks.grid.GetNeighbors(current) {
+			next := keyOf(neighbor)
+			if neighbor.IsObstacle || closed[next] || blockedNodes[next] || blockedEdges[edgeKey{coord, next}] {
+				continue
+			}
+
+			newG := g[coord] + ks.grid.GetCost(current, neighbor)
+			if old, seen := g[next]; seen && newG >= old {
+				continue
+			}
+			g[next] = newG
+			parent[next] = current
+			open.push(neighbor, newG+ks.heuristic(neighbor, goal), -newG)
+		}
+	}
+
+	return RankedPath{}, false
+}
+
+// isDissimilar reports whether candidate is at least minDissimilarity away
+// from every accepted path.
+func (ks *KShortest) isDissimilar(candidate RankedPath, accepted []RankedPath) bool {
+	if ks.minDissimilarity == 0 {
+		return true
+	}
+
+	edges := make(map[edgeKey]bool, len(candidate.Path))
+	for i := 0; i+1 < len(candidate.Path); i++ {
+		a, b := candidate.Path[i], candidate.Path[i+1]
+		edges[edgeKey{keyOf(a), keyOf(b)}] = true
+	}
+
+	for _, other := range accepted {
+		shared := 0.0
+		for i := 0; i+1 < len(other.Path); i++ {
+			a, b := other.Path[i], other.Path[i+1]
+			if edges[edgeKey{keyOf(a), keyOf(b)}] {
+				shared += ks.grid.GetCost(a, b)
+			}
+		}
+
+		cheaper := math.Min(candidate.Cost, other.Cost)
+		if cheaper > 0 && 1-shared/cheaper < ks.minDissimilarity {
+			return false
+		}
+	}
+	return true
+}
+
+// sharesPrefix reports whether path starts with the nodes of root.
+func sharesPrefix(path, root []*Node) bool {
+	for i, node := range root {
+		if !path[i].Equals(node) {
+			return false
+		}
+	}
+	return true
+}
+
+// pathKey returns a string uniquely identifying the nodes of a path. Nodes
+// are identified by ID and coordinates, as in the open and closed sets, so
+// graph nodes that share coordinates are told apart.
+func pathKey(path []*Node) string {
+	var b strings.Builder
+	for _, node := range path {
+		key := keyOf(node)
+		for i, v := range []int{key.id, key.x, key.y, key.z} {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(strconv.Itoa(v))
+		}
+		b.WriteByte(';')
+	}
+	return b.String()
+}
+
+// rankedPathHeap is a min-heap of candidate paths ordered by cost.
+type rankedPathHeap []RankedPath
+
+// Len returns the number of candidates.
+func (h rankedPathHeap) Len() int { return len(h) }
+
+// Less orders candidates by cost, then by length for equal costs.
+func (h rankedPathHeap) Less(i, j int) bool {
+	if h[i].Cost != h[j].Cost {
+		return h[i].Cost < h[j].Cost
+	}
+	return len(h[i].Path) < len(h[j].Path)
+}
+
+// Swap exchanges two candidates.
+func (h rankedPathHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+// Push adds a candidate (heap.Interface).
+func (h *rankedPathHeap) Push(x interface{}) { *h = append(*h, x.(RankedPath)) }
+
+// Pop removes the last candidate (heap.Interface).
+func (h *rankedPathHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,209 @@
+package algo
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+// allSimplePathCosts enumerates the costs of every loopless path between
+// two nodes by depth-first search (only feasible on tiny grids)
+func allSimplePathCosts(grid GridInterface, start, goal *Node) []float64 {
+	var costs []float64
+	visited := map[coordinate]bool{{start.X, start.Y}: true}
+
+	var dfs func(node *Node, cost float64)
+	dfs = func(node *Node, cost float64) {
+		if node.Equals(goal) {
+			costs = append(costs, cost)
+			return
+		}
+		for _, neighbor := range grid.GetNeighbors(node) {
+			coord := coordinate{neighbor.X, neighbor.Y}
+			if visited[coord] {
+				continue
+			}
+			visited[coord] = true
+			dfs(neighbor, cost+grid.GetCost(node, neighbor))
+			delete(visited, coord)
+		}
+	}
+	dfs(start, 0)
+
+	sort.Float64s(costs)
+	return costs
+}
+
+// TestKShortest_ImplementsPathfinder verifies interface compliance
+func TestKShortest_ImplementsPathfinder(t *testing.T) {
+	var _ Pathfinder = NewKShortest()
+}
+
+// TestKShortest_MatchesEnumeration tests that the returned costs equal the
+// k smallest costs over all loopless paths, and that paths are distinct
+func TestKShortest_MatchesEnumeration(t *testing.T) {
+	grid, _ := NewGrid(4, 3, EightWay)
+	costs := [][]float64{
+		{1, 2, 1, 3},
+		{2, 1, 4, 1},
+		{1, 3, 1, 2},
+	}
+	for y, row := range costs {
+		for x, c := range row {
+			node, _ := grid.GetNode(x, y)
+			node.Cost = c
+		}
+	}
+	grid.SetObstacle(2, 1)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(3, 2)
+	want := allSimplePathCosts(grid, start, goal)
+
+	ks := NewKShortest()
+	ks.SetGrid(grid)
+	ks.SetHeuristic(Zero)
+
+	const k = 25
+	paths, err := ks.KShortestPaths(start, goal, k)
+	if err != nil {
+		t.Fatalf("KShortestPaths() returned error: %v", err)
+	}
+
+	if len(paths) != k {
+		t.Fatalf("Expected %d paths, got %d", k, len(paths))
+	}
+
+	seen := make(map[string]bool)
+	for i, p := range paths {
+		assertContiguousPath(t, grid, p.Path)
+
+		if math.Abs(p.Cost-want[i]) > 1e-9 {
+			t.Errorf("path %d: cost %.3f, expected %.3f", i, p.Cost, want[i])
+		}
+		if math.Abs(PathCost(grid, p.Path)-p.Cost) > 1e-9 {
+			t.Errorf("path %d: reported cost %.3f, actual %.3f", i, p.Cost, PathCost(grid, p.Path))
+		}
+
+		key := pathKey(p.Path)
+		if seen[key] {
+			t.Errorf("path %d is a duplicate", i)
+		}
+		seen[key] = true
+
+		cells := make(map[coordinate]bool)
+		for _, node := range p.Path {
+			if cells[coordinate{node.X, node.Y}] {
+				t.Errorf("path %d contains a loop at (%d, %d)", i, node.X, node.Y)
+			}
+			cells[coordinate{node.X, node.Y}] = true
+		}
+	}
+}
+
+// TestKShortest_ExhaustsPaths tests that fewer than k paths are returned
+// when no more exist
+func TestKShortest_ExhaustsPaths(t *testing.T) {
+	grid, _ := NewGrid(3, 2, FourWay)
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(2, 0)
+
+	ks := NewKShortest()
+	ks.SetGrid(grid)
+
+	paths, err := ks.KShortestPaths(start, goal, 10)
+	if err != nil {
+		t.Fatalf("KShortestPaths() returned error: %v", err)
+	}
+
+	want := allSimplePathCosts(grid, start, goal)
+	if len(paths) != len(want) {
+		t.Errorf("Expected all %d loopless paths, got %d", len(want), len(paths))
+	}
+}
+
+// TestKShortest_MinDissimilarity tests that alternatives avoid the edges of
+// earlier paths when full dissimilarity is required
+func TestKShortest_MinDissimilarity(t *testing.T) {
+	grid, _ := NewGrid(7, 5, FourWay)
+	// Block the middle so there is a northern and a southern route
+	for x := 1; x < 6; x++ {
+		for y := 1; y < 4; y++ {
+			grid.SetObstacle(x, y)
+		}
+	}
+
+	start, _ := grid.GetNode(0, 2)
+	goal, _ := grid.GetNode(6, 2)
+
+	ks := NewKShortest()
+	ks.SetGrid(grid)
+
+	paths, _ := ks.KShortestPaths(start, goal, 2)
+	if len(paths) != 2 {
+		t.Fatalf("Expected 2 paths, got %d", len(paths))
+	}
+
+	if err := ks.SetMinDissimilarity(1); err != nil {
+		t.Fatalf("SetMinDissimilarity() returned error: %v", err)
+	}
+
+	paths, err := ks.KShortestPaths(start, goal, 3)
+	if err != nil {
+		t.Fatalf("KShortestPaths() returned error: %v", err)
+	}
+
+	// Only the northern and southern routes are edge-disjoint
+	if len(paths) != 2 {
+		t.Fatalf("Expected 2 edge-disjoint paths, got %d", len(paths))
+	}
+
+	north := pathContains(paths[0].Path, 3, 0) || pathContains(paths[1].Path, 3, 0)
+	south := pathContains(paths[0].Path, 3, 4) || pathContains(paths[1].Path, 3, 4)
+	if !north || !south {
+		t.Error("Expected one northern and one southern route")
+	}
+}
+
+// TestKShortest_InputValidation tests error handling for invalid inputs
+func TestKShortest_InputValidation(t *testing.T) {
+	ks := NewKShortest()
+	grid, _ := NewGrid(5, 5, FourWay)
+	node, _ := grid.GetNode(0, 0)
+	other, _ := grid.GetNode(4, 2)
+
+	if _, err := ks.KShortestPaths(node, other, 2); err == nil {
+		t.Error("KShortestPaths() should return error when grid is not set")
+	}
+
+	ks.SetGrid(grid)
+	if _, err := ks.KShortestPaths(node, other, 0); err == nil {
+		t.Error("KShortestPaths() should return error for k = 0")
+	}
+
+	if err := ks.SetMinDissimilarity(1.5); err == nil {
+		t.Error("SetMinDissimilarity(1.5) should return error")
+	}
+
+	if err := ks.SetMaxCandidates(0); err == nil {
+		t.Error("SetMaxCandidates(0) should return error")
+	}
+
+	ks.SetHeuristic(nil)
+	if _, err := ks.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when heuristic is nil")
+	}
+
+	ks.SetHeuristic(Manhattan)
+	paths, err := ks.KShortestPaths(node, node, 3)
+	if err != nil || len(paths) != 1 || len(paths[0].Path) != 1 {
+		t.Errorf("Expected single one-node path for same start/goal, got %v (err=%v)", paths, err)
+	}
+
+	for y := 0; y < 5; y++ {
+		grid.SetObstacle(2, y)
+	}
+	if _, err := ks.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when no path exists")
+	}
+}
@@ -0,0 +1,465 @@
+package algo
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// LandmarkStrategy selects how NewLandmarks places landmarks.
+type LandmarkStrategy int
+
+const (
+	// LandmarkFarthest repeatedly picks the cell farthest (by path cost)
+	// from all landmarks chosen so far
+	LandmarkFarthest LandmarkStrategy = iota
+	// LandmarkAvoid picks landmarks in regions where the current landmarks
+	// give poor bounds (Goldberg & Werneck's "avoid" heuristic)
+	LandmarkAvoid
+)
+
+// landmarkMagic identifies serialized landmark tables (format version 1).
+var landmarkMagic = [4]byte{'A', 'L', 'T', '1'}
+
+// Limits on serialized landmark tables. ReadLandmarks rejects headers beyond
+// them, and reads tables in chunks of landmarkReadChunk values so that
+// memory grows with the data actually present, not with the header's claim.
+const (
+	maxLandmarkCells  = 1 << 24
+	maxLandmarks      = 256
+	landmarkReadChunk = 1 << 12
+)
+
+// Landmarks holds precomputed shortest-path distances between a few
+// landmark cells and every cell of a Grid, for the ALT heuristic
+// (A*, Landmarks, Triangle inequality; Goldberg & Harrelson, 2005).
+//
+// For any landmark L the triangle inequality gives two lower bounds on the
+// cost d(v, t) of a path from v to t:
+//
+//	d(v, t) >= d(L, t) - d(L, v)
+//	d(v, t) >= d(v, L) - d(t, L)
+//
+// The heuristic takes the largest bound over all landmarks. It is
+// admissible and consistent on weighted grids, and is usually far more
+// accurate than geometric heuristics on maze-like maps.
+//
+// Tables are only valid for the grid they were computed on: recompute them
+// when obstacles or costs change. Memory use is 2 × k × width × height
+// float64 values.
+type Landmarks struct {
+	// width and height are the dimensions of the grid the tables cover
+	width, height int
+
+	// positions are the landmark cells
+	positions []coordinate
+
+	// from[i][y*width+x] is the cost from landmark i to (x, y)
+	from [][]float64
+
+	// to[i][y*width+x] is the cost from (x, y) to landmark i
+	to [][]float64
+}
+
+// NewLandmarks selects k landmarks on the grid and computes the distance
+// tables for them. Neighbor relations are assumed to be symmetric, as they
+// are for Grid.
+//
+// Params:
+//
+//	grid: grid to preprocess
+//	k: number of landmarks (must be > 0 and at most the number of walkable cells)
+//	strategy: landmark selection strategy
+//
+// Returns:
+//
+//	*Landmarks: landmark tables
+//	error: if grid is nil, k is out of range or strategy is unknown
+func NewLandmarks(grid *Grid, k int, strategy LandmarkStrategy) (*Landmarks, error) {
+	if grid == nil {
+		return nil, errors.New("grid cannot be nil")
+	}
+
+	if strategy != LandmarkFarthest && strategy != LandmarkAvoid {
+		return nil, fmt.Errorf("unknown landmark strategy %d", strategy)
+	}
+
+	walkable := 0
+	var first *Node
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			if node := grid.nodes[y][x]; !node.IsObstacle {
+				if first == nil {
+					first = node
+				}
+				walkable++
+			}
+		}
+	}
+
+	if k <= 0 || k > walkable {
+		return nil, fmt.Errorf("landmark count must be between 1 and %d walkable cells, got %d", walkable, k)
+	}
+
+	l := &Landmarks{width: grid.Width, height: grid.Height}
+	for len(l.positions) < k {
+		var landmark *Node
+		if strategy == LandmarkAvoid {
+			landmark = l.selectAvoid(grid, first)
+		} else {
+			landmark = l.selectFarthest(grid, first)
+		}
+		l.add(grid, landmark)
+	}
+
+	return l, nil
+}
+
+// Count returns the number of landmarks.
+//
+// Returns:
+//
+//	int: number of landmarks
+func (l *Landmarks) Count() int {
+	return len(l.positions)
+}
+
+// Positions returns the coordinates of the landmarks.
+//
+// Returns:
+//
+//	[][2]int: landmark coordinates as {x, y} pairs
+func (l *Landmarks) Positions() [][2]int {
+	positions := make([][2]int, len(l.positions))
+	for i, p := range l.positions {
+		positions[i] = [2]int{p.x, p.y}
+	}
+	return positions
+}
+
+// Heuristic returns the ALT heuristic as a HeuristicFunc for use with
+// AStar or any other pathfinder. Nodes outside the tables get 0.
+//
+// Returns:
+//
+//	HeuristicFunc: admissible landmark-based heuristic
+func (l *Landmarks) Heuristic() HeuristicFunc {
+	return func(current, goal *Node) float64 {
+		v, okV := l.index(current.X, current.Y)
+		t, okT := l.index(goal.X, goal.Y)
+		if !okV || !okT {
+			return 0
+		}
+		return l.lowerBound(v, t)
+	}
+}
+
+// WriteTo serializes the landmark tables in a compact little-endian binary
+// format, so they can be stored and loaded with ReadLandmarks at startup.
+//
+// Params:
+//
+//	w: destination writer
+//
+// Returns:
+//
+//	int64: number of bytes written
+//	error: if writing fails
+func (l *Landmarks) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+
+	header := []uint32{uint32(l.width), uint32(l.height), uint32(len(l.positions))}
+	data := []any{landmarkMagic, header}
+	for _, p := range l.positions {
+		data = append(data, [2]uint32{uint32(p.x), uint32(p.y)})
+	}
+	for i := range l.positions {
+		data = append(data, l.from[i], l.to[i])
+	}
+
+	for _, d := range data {
+		if err := binary.Write(bw, binary.LittleEndian, d); err != nil {
+			return cw.n, err
+		}
+	}
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// ReadLandmarks loads landmark tables written by Landmarks.WriteTo.
+// The caller is responsible for using them with the same grid. Headers
+// describing more than 2^24 cells or 256 landmarks are rejected, and a
+// header that claims more data than the stream holds fails once the stream
+// ends, without allocating the claimed size up front.
+//
+// Params:
+//
+//	r: source reader
+//
+// Returns:
+//
+//	*Landmarks: loaded landmark tables
+//	error: if the data is malformed or reading fails
+func ReadLandmarks(r io.Reader) (*Landmarks, error) {
+	br := bufio.NewReader(r)
+
+	var magic [4]byte
+	if err := binary.Read(br, binary.LittleEndian, &magic); err != nil {
+		return nil, fmt.Errorf("reading landmark header: %w", err)
+	}
+	if magic != landmarkMagic {
+		return nil, errors.New("not a landmark table (bad magic)")
+	}
+
+	var header [3]uint32
+	if err := binary.Read(br, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("reading landmark header: %w", err)
+	}
+	width, height, k := int(header[0]), int(header[1]), int(header[2])
+	if width <= 0 || height <= 0 || k <= 0 || k > width*height {
+		return nil, fmt.Errorf("invalid landmark header: %dx%d grid with %d landmarks", width, height, k)
+	}
+	if uint64(header[0])*uint64(header[1]) > maxLandmarkCells || k > maxLandmarks {
+		return nil, fmt.Errorf("landmark header too large: %dx%d grid with %d landmarks", width, height, k)
+	}
+
+	l := &Landmarks{width: width, height: height}
+	for i := 0; i < k; i++ {
+		var p [2]uint32
+		if err := binary.Read(br, binary.LittleEndian, &p); err != nil {
+			return nil, fmt.Errorf("reading landmark positions: %w", err)
+		}
+		if int(p[0]) >= width || int(p[1]) >= height {
+			return nil, fmt.Errorf("landmark (%d, %d) is out of bounds for grid %dx%d", p[0], p[1], width, height)
+		}
+		l.positions = append(l.positions, coordinate{int(p[0]), int(p[1])})
+	}
+
+	for i := 0; i < k; i++ {
+		from, err := readLandmarkTable(br, width*height)
+		if err != nil {
+			return nil, err
+		}
+		to, err := readLandmarkTable(br, width*height)
+		if err != nil {
+			return nil, err
+		}
+		l.from = append(l.from, from)
+		l.to = append(l.to, to)
+	}
+
+	return l, nil
+}
+
+// readLandmarkTable reads n little-endian float64 values in chunks, so a
+// short stream fails before the whole table is allocated.
+func readLandmarkTable(r io.Reader, n int) ([]float64, error) {
+	table := make([]float64, 0, min(n, landmarkReadChunk))
+	for len(table) < n {
+		chunk := make([]float64, min(n-len(table), landmarkReadChunk))
+		if err := binary.Read(r, binary.LittleEndian, chunk); err != nil {
+			return nil, fmt.Errorf("reading landmark table: %w", err)
+		}
+		table = append(table, chunk...)
+	}
+	return table, nil
+}
+
+// lowerBound returns the best triangle-inequality bound on the cost from
+// cell index v to cell index t. Unreachable (+Inf) entries give no bound.
+func (l *Landmarks) lowerBound(v, t int) float64 {
+	best := 0.0
+	for i := range l.positions {
+		from, to := l.from[i], l.to[i]
+		if !math.IsInf(from[t], 1) && !math.IsInf(from[v], 1) {
+			best = math.Max(best, from[t]-from[v])
+		}
+		if !math.IsInf(to[v], 1) && !math.IsInf(to[t], 1) {
+			best = math.Max(best, to[v]-to[t])
+		}
+	}
+	return best
+}
+
+// add makes node a landmark and computes its distance tables.
+func (l *Landmarks) add(grid *Grid, node *Node) {
+	from, _, _ := l.distances(grid, node, false)
+	to, _, _ := l.distances(grid, node, true)
+	l.positions = append(l.positions, coordinate{node.X, node.Y})
+	l.from = append(l.from, from)
+	l.to = append(l.to, to)
+}
+
+// selectFarthest returns the walkable non-landmark cell with the largest
+// cost from its nearest landmark. The first landmark is the cell farthest
+// from start. Cells no landmark can reach count as infinitely far, so
+// disconnected regions receive landmarks too.
+func (l *Landmarks) selectFarthest(grid *Grid, start *Node) *Node {
+	nearest := make([]float64, l.width*l.height)
+	if len(l.positions) == 0 {
+		nearest, _, _ = l.distances(grid, start, false)
+	} else {
+		for v := range nearest {
+			nearest[v] = math.Inf(1)
+			for i := range l.positions {
+				nearest[v] = math.Min(nearest[v], l.from[i][v])
+			}
+		}
+	}
+
+	var best *Node
+	bestDist := -1.0
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			node := grid.nodes[y][x]
+			v := y*l.width + x
+			if node.IsObstacle || l.isLandmark(x, y) || nearest[v] <= bestDist {
+				continue
+			}
+			best, bestDist = node, nearest[v]
+		}
+	}
+	return best
+}
+
+// selectAvoid implements the "avoid" strategy. A root is chosen (the cell
+// farthest from the current landmarks) and its shortest-path tree is
+// built. Each cell is weighted by how much the current bound
+// underestimates its distance from the root; the search descends into the
+// heaviest subtree that contains no landmark and picks the leaf it ends at.
+func (l *Landmarks) selectAvoid(grid *Grid, start *Node) *Node {
+	root := start
+	if len(l.positions) > 0 {
+		root = l.selectFarthest(grid, start)
+	}
+	rootIndex := root.Y*l.width + root.X
+
+	dist, parent, order := l.distances(grid, root, false)
+
+	// Accumulate subtree weights bottom-up (order lists parents before children)
+	size := make([]float64, len(dist))
+	blocked := make([]bool, len(dist))
+	for i := len(order) - 1; i >= 0; i-- {
+		v := order[i]
+		x, y := v%l.width, v/l.width
+		if l.isLandmark(x, y) {
+			blocked[v] = true
+		}
+		if !blocked[v] {
+			size[v] += dist[v] - l.lowerBound(rootIndex, v)
+		}
+		if p := parent[v]; p >= 0 {
+			if blocked[v] {
+				blocked[p] = true
+			}
+			size[p] += size[v]
+		}
+	}
+
+	children := make(map[int][]int)
+	for _, v := range order {
+		if p := parent[v]; p >= 0 {
+			children[p] = append(children[p], v)
+		}
+	}
+
+	// Descend into the heaviest landmark-free subtree until reaching a leaf
+	v := rootIndex
+	for {
+		next, nextSize := -1, -1.0
+		for _, c := range children[v] {
+			if !blocked[c] && size[c] > nextSize {
+				next, nextSize = c, size[c]
+			}
+		}
+		if next < 0 {
+			break
+		}
+		v = next
+	}
+
+	return grid.nodes[v/l.width][v%l.width]
+}
+
+// distances runs Dijkstra's algorithm from source. With reverse set it
+// computes costs to source instead, using GetCost(neighbor, node).
+//
+// Returns:
+//
+//	[]float64: cost per cell index (+Inf if unreachable)
+//	[]int: shortest-path tree parent per cell index (-1 for none)
+//	[]int: reachable cell indices in the order they were settled
+func (l *Landmarks) distances(grid *Grid, source *Node, reverse bool) ([]float64, []int, []int) {
+	dist := make([]float64, l.width*l.height)
+	parent := make([]int, l.width*l.height)
+	for i := range dist {
+		dist[i] = math.Inf(1)
+		parent[i] = -1
+	}
+
+	dist[source.Y*l.width+source.X] = 0
+	var order []int
+	var open entryQueue
+	open.push(source, 0, 0)
+
+	for open.Len() > 0 {
+		entry := open.pop()
+		u := entry.node
+		ui := u.Y*l.width + u.X
+		if entry.priority > dist[ui] {
+			continue
+		}
+		order = append(order, ui)
+
+		for _, n := range grid.GetNeighbors(u) {
+			cost := grid.GetCost(u, n)
+			if reverse {
+				cost = grid.GetCost(n, u)
+			}
+
+			ni := n.Y*l.width + n.X
+			if d := dist[ui] + cost; d < dist[ni] {
+				dist[ni] = d
+				parent[ni] = ui
+				open.push(n, d, 0)
+			}
+		}
+	}
+
+	return dist, parent, order
+}
+
+// index converts coordinates to a table index.
+func (l *Landmarks) index(x, y int) (int, bool) {
+	if x < 0 || x >= l.width || y < 0 || y >= l.height {
+		return 0, false
+	}
+	return y*l.width + x, true
+}
+
+// isLandmark reports whether (x, y) is already a landmark.
+func (l *Landmarks) isLandmark(x, y int) bool {
+	for _, p := range l.positions {
+		if p.x == x && p.y == y {
+			return true
+		}
+	}
+	return false
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write forwards p to the underlying writer and counts the bytes written.
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,221 @@
+package algo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+// newLandmarkMaze builds a weighted serpentine maze where geometric
+// heuristics badly underestimate the remaining cost
+func newLandmarkMaze() *Grid {
+	grid, _ := NewGrid(30, 30, EightWay)
+	rng := rand.New(rand.NewSource(5))
+	for y := 0; y < 30; y++ {
+		for x := 0; x < 30; x++ {
+			node, _ := grid.GetNode(x, y)
+			node.Cost = float64(1 + rng.Intn(3))
+		}
+	}
+	// Horizontal walls with gaps alternating between the left and right ends
+	for y := 3; y < 30; y += 4 {
+		for x := 0; x < 30; x++ {
+			if (y/4)%2 == 0 && x == 29 || (y/4)%2 == 1 && x == 0 {
+				continue
+			}
+			grid.SetObstacle(x, y)
+		}
+	}
+	return grid
+}
+
+// TestNewLandmarks_InputValidation tests error handling for invalid inputs
+func TestNewLandmarks_InputValidation(t *testing.T) {
+	grid, _ := NewGrid(3, 3, FourWay)
+
+	if _, err := NewLandmarks(nil, 2, LandmarkFarthest); err == nil {
+		t.Error("NewLandmarks() should return error for nil grid")
+	}
+
+	if _, err := NewLandmarks(grid, 0, LandmarkFarthest); err == nil {
+		t.Error("NewLandmarks() should return error for k = 0")
+	}
+
+	if _, err := NewLandmarks(grid, 10, LandmarkFarthest); err == nil {
+		t.Error("NewLandmarks() should return error when k exceeds walkable cells")
+	}
+
+	if _, err := NewLandmarks(grid, 2, LandmarkStrategy(99)); err == nil {
+		t.Error("NewLandmarks() should return error for unknown strategy")
+	}
+
+	l, err := NewLandmarks(grid, 9, LandmarkAvoid)
+	if err != nil {
+		t.Fatalf("NewLandmarks() with k = walkable cells returned error: %v", err)
+	}
+	if l.Count() != 9 {
+		t.Errorf("Expected 9 landmarks, got %d", l.Count())
+	}
+}
+
+// TestNewLandmarks_Farthest tests that farthest selection spreads
+// landmarks to the ends of the map
+func TestNewLandmarks_Farthest(t *testing.T) {
+	grid, _ := NewGrid(10, 10, FourWay)
+	l, err := NewLandmarks(grid, 2, LandmarkFarthest)
+	if err != nil {
+		t.Fatalf("NewLandmarks() returned error: %v", err)
+	}
+
+	// The first landmark is farthest from (0, 0), the second farthest from it
+	want := [][2]int{{9, 9}, {0, 0}}
+	if got := l.Positions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected landmarks %v, got %v", want, got)
+	}
+}
+
+// TestLandmarks_Heuristic_Admissible tests that the ALT heuristic never
+// overestimates on a weighted maze, for both strategies
+func TestLandmarks_Heuristic_Admissible(t *testing.T) {
+	grid := newLandmarkMaze()
+	dijkstra := NewDijkstra()
+	dijkstra.SetGrid(grid)
+	rng := rand.New(rand.NewSource(9))
+
+	for _, strategy := range []LandmarkStrategy{LandmarkFarthest, LandmarkAvoid} {
+		l, err := NewLandmarks(grid, 4, strategy)
+		if err != nil {
+			t.Fatalf("NewLandmarks() returned error: %v", err)
+		}
+
+		seen := make(map[[2]int]bool)
+		for _, p := range l.Positions() {
+			if seen[p] || grid.IsObstacle(p[0], p[1]) {
+				t.Errorf("strategy %d: invalid or duplicate landmark %v", strategy, p)
+			}
+			seen[p] = true
+		}
+
+		heuristic := l.Heuristic()
+		for i := 0; i < 40; i++ {
+			from, _ := grid.GetNode(rng.Intn(30), rng.Intn(30))
+			to, _ := grid.GetNode(rng.Intn(30), rng.Intn(30))
+			if from.IsObstacle || to.IsObstacle {
+				continue
+			}
+
+			path, err := dijkstra.FindPath(from, to)
+			if err != nil {
+				t.Fatalf("Dijkstra FindPath() returned error: %v", err)
+			}
+			if h, cost := heuristic(from, to), PathCost(grid, path); h > cost+1e-9 {
+				t.Errorf("strategy %d: h(%d,%d -> %d,%d) = %.3f exceeds cost %.3f",
+					strategy, from.X, from.Y, to.X, to.Y, h, cost)
+			}
+		}
+	}
+}
+
+// TestLandmarks_Heuristic_AStar tests that A* with the ALT heuristic stays
+// optimal and expands fewer nodes than with a geometric heuristic
+func TestLandmarks_Heuristic_AStar(t *testing.T) {
+	grid := newLandmarkMaze()
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(0, 29)
+
+	l, err := NewLandmarks(grid, 4, LandmarkAvoid)
+	if err != nil {
+		t.Fatalf("NewLandmarks() returned error: %v", err)
+	}
+
+	astar := NewAStar()
+	astar.SetGrid(grid)
+	astar.SetHeuristic(Diagonal)
+	geometric, err := astar.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search() with Diagonal returned error: %v", err)
+	}
+
+	astar.SetHeuristic(l.Heuristic())
+	alt, err := astar.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search() with ALT returned error: %v", err)
+	}
+
+	if math.Abs(alt.Cost-geometric.Cost) > 1e-9 {
+		t.Errorf("ALT cost %.3f differs from optimal %.3f", alt.Cost, geometric.Cost)
+	}
+
+	if alt.NodesExpanded >= geometric.NodesExpanded {
+		t.Errorf("ALT expanded %d nodes, expected fewer than Diagonal's %d",
+			alt.NodesExpanded, geometric.NodesExpanded)
+	}
+
+	outside := NewNode(100, 100)
+	if h := l.Heuristic()(outside, goal); h != 0 {
+		t.Errorf("Expected 0 for nodes outside the tables, got %.3f", h)
+	}
+}
+
+// TestLandmarks_Serialization tests a write/read round trip and malformed input
+func TestLandmarks_Serialization(t *testing.T) {
+	grid := newLandmarkMaze()
+	l, err := NewLandmarks(grid, 3, LandmarkFarthest)
+	if err != nil {
+		t.Fatalf("NewLandmarks() returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	n, err := l.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo() returned error: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo() reported %d bytes, buffer holds %d", n, buf.Len())
+	}
+
+	loaded, err := ReadLandmarks(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("ReadLandmarks() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(l, loaded) {
+		t.Error("Loaded landmarks differ from the original")
+	}
+
+	if _, err := ReadLandmarks(bytes.NewReader([]byte("nope"))); err == nil {
+		t.Error("ReadLandmarks() should reject data with a bad magic")
+	}
+
+	truncated := buf.Bytes()[:buf.Len()/2]
+	if _, err := ReadLandmarks(bytes.NewReader(truncated)); err == nil {
+		t.Error("ReadLandmarks() should reject truncated data")
+	}
+
+	// A header claiming huge tables is rejected before anything is allocated
+	huge := append(landmarkMagic[:0:0], landmarkMagic[:]...)
+	huge = binary.LittleEndian.AppendUint32(huge, 65535)
+	huge = binary.LittleEndian.AppendUint32(huge, 65535)
+	huge = binary.LittleEndian.AppendUint32(huge, 1)
+	if _, err := ReadLandmarks(bytes.NewReader(huge)); err == nil {
+		t.Error("ReadLandmarks() should reject oversized headers")
+	}
+
+	// A plausible header with missing tables fails once the stream ends
+	short := append(landmarkMagic[:0:0], landmarkMagic[:]...)
+	for _, v := range []uint32{4096, 4096, 1, 0, 0} {
+		short = binary.LittleEndian.AppendUint32(short, v)
+	}
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	if _, err := ReadLandmarks(bytes.NewReader(short)); err == nil {
+		t.Error("ReadLandmarks() should reject a header without tables")
+	}
+	runtime.ReadMemStats(&after)
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 1<<20 {
+		t.Errorf("ReadLandmarks() allocated %d bytes for a 32-byte input", allocated)
+	}
+}
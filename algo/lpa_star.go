@@ -0,0 +1,248 @@
+package algo
+
+import (
+	"fmt"
+	"math"
+)
+
+// LPAStar implements Lifelong Planning A* (Koenig, Likhachev & Furcy, 2004).
+// LPA* answers repeated queries for the same start/goal pair while edge
+// costs change. It keeps g and rhs values between calls and, after being
+// notified of changed cells, only re-expands nodes whose cost-from-start
+// became inconsistent. The first search costs the same as A*; subsequent
+// searches after small edits are typically much cheaper.
+//
+// Typical usage in an editor:
+//
+//	planner := NewLPAStar()
+//	planner.SetGrid(grid)
+//	path, err := planner.FindPath(start, goal)
+//	node.Cost = 5                      // designer paints terrain
+//	planner.NotifyChanged(node)
+//	path, err = planner.FindPath(start, goal) // incremental update
+//
+// Calling FindPath with a different start or goal starts a fresh search.
+// On directed search spaces such as Graph, predecessors are taken from
+// GetPredecessors; otherwise moves are assumed to be symmetric, as they are
+// for Grid.
+type LPAStar struct {
+	// grid is the search space we're pathfinding within
+	grid SearchSpace
+
+	// heuristic function estimates cost from current node to goal
+	heuristic HeuristicFunc
+
+	// start and goal of the query whose state is being kept
+	start, goal *Node
+
+	// incrementalState holds cost-from-start estimates and the open set
+	incrementalState
+
+	// expanded counts nodes expanded by the most recent FindPath
+	expanded int
+}
+
+// NewLPAStar creates a new Lifelong Planning A* instance.
+// By default, it uses the Manhattan heuristic.
+//
+// Returns:
+//
+//	*LPAStar: new LPA* instance
+func NewLPAStar() *LPAStar {
+	return &LPAStar{
+		heuristic:        Manhattan,
+		incrementalState: newIncrementalState(),
+	}
+}
+
+// SetGrid configures the grid/search space for the pathfinder.
+// Changing the grid discards the preserved search state.
+//
+// Params:
+//
+//	grid: grid to search within
+func (l *LPAStar) SetGrid(grid SearchSpace) {
+	l.grid = grid
+	l.goal = nil
+}
+
+// SetHeuristic configures the heuristic function for the pathfinder.
+// The heuristic must be admissible and consistent. Changing the heuristic
+// discards the preserved search state.
+//
+// Params:
+//
+//	heuristic: heuristic function to use
+func (l *LPAStar) SetHeuristic(heuristic HeuristicFunc) {
+	l.heuristic = heuristic
+	l.goal = nil
+}
+
+// FindPath returns the optimal path from start to goal. If the same
+// start/goal pair was queried before, the preserved search state is repaired
+// incrementally using the cells reported through NotifyChanged.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	[]*Node: path from start to goal (including both endpoints)
+//	error: if no path exists or invalid input
+func (l *LPAStar) FindPath(start, goal *Node) ([]*Node, error) {
+	if err := validateEndpoints(l.grid, start, goal); err != nil {
+		return nil, err
+	}
+
+	if l.heuristic == nil {
+		return nil, fmt.Errorf("heuristic must be set before calling FindPath")
+	}
+
+	if l.goal == nil || !l.start.Equals(start) || !l.goal.Equals(goal) {
+		l.initialize(start, goal)
+	}
+
+	l.computeShortestPath()
+
+	if math.IsInf(l.getG(goal), 1) {
+		return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+			start.X, start.Y, goal.X, goal.Y)
+	}
+
+	return l.extractPath()
+}
+
+// NotifyChanged informs the planner that the given cells changed, e.g. after
+// editing Node.Cost or toggling obstacles. The cells and their successors are
+// marked for repair on the next FindPath with the same start and goal.
+// Notifications before the first FindPath are ignored.
+//
+// Params:
+//
+//	nodes: cells whose cost or obstacle status changed
+func (l *LPAStar) NotifyChanged(nodes ...*Node) {
+	if l.goal == nil {
+		return
+	}
+
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		l.updateVertex(node)
+		for _, succ := range l.grid.GetNeighbors(node) {
+			l.updateVertex(succ)
+		}
+	}
+}
+
+// NodesExpanded returns the number of nodes expanded by the most recent
+// FindPath.
+//
+// Returns:
+//
+//	int: number of expanded nodes
+func (l *LPAStar) NodesExpanded() int {
+	return l.expanded
+}
+
+// initialize discards preserved state and seeds a search for a new query.
+func (l *LPAStar) initialize(start, goal *Node) {
+	l.start, l.goal = start, goal
+	l.reset()
+
+	l.setRHS(start, 0)
+	l.insert(start)
+}
+
+// computeShortestPath expands inconsistent nodes until the goal is
+// consistent and no queued node could improve it.
+func (l *LPAStar) computeShortestPath() {
+	l.expanded = 0
+
+	for {
+		top, ok := l.topKey()
+		if !ok {
+			return
+		}
+		if !top.less(l.calculateKey(l.goal)) && l.consistent(l.goal) {
+			return
+		}
+
+		u, _ := l.pop()
+		l.expanded++
+
+		if l.getG(u) > l.getRHS(u) {
+			// Overconsistent: settle g and propagate to successors
+			l.setG(u, l.getRHS(u))
+			for _, succ := range l.grid.GetNeighbors(u) {
+				l.updateVertex(succ)
+			}
+		} else {
+			// Underconsistent: invalidate g and recompute u and its successors
+			l.setG(u, math.Inf(1))
+			l.updateVertex(u)
+			for _, succ := range l.grid.GetNeighbors(u) {
+				l.updateVertex(succ)
+			}
+		}
+	}
+}
+
+// updateVertex recomputes rhs(u) from its predecessors and (re)queues u if
+// it is inconsistent.
+func (l *LPAStar) updateVertex(u *Node) {
+	if !u.Equals(l.start) {
+		_, best := l.cheapest(predecessors(l.grid, u), func(pred *Node) float64 {
+			return l.cost(pred, u)
+		})
+		l.setRHS(u, best)
+	}
+	l.requeue(u, l.calculateKey(u))
+}
+
+// extractPath follows the cheapest predecessor from the goal back to the start.
+func (l *LPAStar) extractPath() ([]*Node, error) {
+	path := []*Node{l.goal}
+	visited := map[nodeKey]bool{keyOf(l.goal): true}
+
+	for current := l.goal; !current.Equals(l.start); {
+		prev, _ := l.cheapest(predecessors(l.grid, current), func(pred *Node) float64 {
+			return l.cost(pred, current)
+		})
+
+		if prev == nil || visited[keyOf(prev)] {
+			return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+				l.start.X, l.start.Y, l.goal.X, l.goal.Y)
+		}
+
+		visited[keyOf(prev)] = true
+		path = append(path, prev)
+		current = prev
+	}
+
+	reversePath(path)
+	return path, nil
+}
+
+// cost returns the movement cost between two adjacent nodes, or +Inf if
+// either is an obstacle.
+func (l *LPAStar) cost(from, to *Node) float64 {
+	if from.IsObstacle || to.IsObstacle {
+		return math.Inf(1)
+	}
+	return l.grid.GetCost(from, to)
+}
+
+// calculateKey computes the priority of a node.
+func (l *LPAStar) calculateKey(node *Node) dstarKey {
+	m := math.Min(l.getG(node), l.getRHS(node))
+	return dstarKey{m + l.heuristic(node, l.goal), m}
+}
+
+// insert queues a node with its current key.
+func (l *LPAStar) insert(node *Node) {
+	l.enqueue(node, l.calculateKey(node))
+}
@@ -0,0 +1,185 @@
+package algo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestLPAStar_ImplementsPathfinder verifies interface compliance
+func TestLPAStar_ImplementsPathfinder(t *testing.T) {
+	var _ Pathfinder = NewLPAStar()
+}
+
+// TestLPAStar_FindPath_SimpleCase tests the initial search
+func TestLPAStar_FindPath_SimpleCase(t *testing.T) {
+	grid, _ := NewGrid(5, 5, FourWay)
+	l := NewLPAStar()
+	l.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(4, 4)
+
+	path, err := l.FindPath(start, goal)
+	if err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+
+	if len(path) != 9 {
+		t.Errorf("Expected path length 9, got %d", len(path))
+	}
+
+	if !path[0].Equals(start) || !path[len(path)-1].Equals(goal) {
+		t.Error("Path should run from start to goal")
+	}
+
+	assertContiguousPath(t, grid, path)
+}
+
+// TestLPAStar_CostEdits_MatchDijkstra paints random costs and obstacles
+// between queries and checks every incremental answer is optimal
+func TestLPAStar_CostEdits_MatchDijkstra(t *testing.T) {
+	rng := rand.New(rand.NewSource(9))
+
+	for _, movement := range []MovementType{FourWay, EightWay} {
+		grid, _ := NewGrid(20, 20, movement)
+		start, _ := grid.GetNode(1, 1)
+		goal, _ := grid.GetNode(18, 18)
+
+		l := NewLPAStar()
+		l.SetGrid(grid)
+		l.SetHeuristic(Diagonal)
+
+		for edit := 0; edit < 30; edit++ {
+			path, err := l.FindPath(start, goal)
+
+			dijkstra := NewDijkstra()
+			dijkstra.SetGrid(grid)
+			expected, dErr := dijkstra.FindPath(start, goal)
+
+			if (err == nil) != (dErr == nil) {
+				t.Fatalf("edit %d: LPA* err=%v, Dijkstra err=%v", edit, err, dErr)
+			}
+			if err == nil {
+				if math.Abs(PathCost(grid, path)-PathCost(grid, expected)) > 1e-9 {
+					t.Errorf("edit %d: LPA* cost %.3f differs from Dijkstra cost %.3f",
+						edit, PathCost(grid, path), PathCost(grid, expected))
+				}
+				assertContiguousPath(t, grid, path)
+			}
+
+			// Paint a small brush of cells
+			var painted []*Node
+			cx, cy := rng.Intn(20), rng.Intn(20)
+			for dx := -1; dx <= 1; dx++ {
+				for dy := -1; dy <= 1; dy++ {
+					node, err := grid.GetNode(cx+dx, cy+dy)
+					if err != nil || node.Equals(start) || node.Equals(goal) {
+						continue
+					}
+					if rng.Float64() < 0.3 {
+						node.IsObstacle = !node.IsObstacle
+					} else {
+						node.Cost = 1 + float64(rng.Intn(5))
+					}
+					painted = append(painted, node)
+				}
+			}
+			l.NotifyChanged(painted...)
+		}
+	}
+}
+
+// TestLPAStar_Incremental tests that a small edit re-expands far fewer nodes
+// than the initial search
+func TestLPAStar_Incremental(t *testing.T) {
+	grid, _ := NewGrid(50, 50, FourWay)
+	l := NewLPAStar()
+	l.SetGrid(grid)
+
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(49, 49)
+
+	if _, err := l.FindPath(start, goal); err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+	initial := l.NodesExpanded()
+
+	node, _ := grid.GetNode(45, 3)
+	node.Cost = 3.0
+	l.NotifyChanged(node)
+
+	if _, err := l.FindPath(start, goal); err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+
+	if l.NodesExpanded() >= initial {
+		t.Errorf("Incremental search expanded %d nodes, expected fewer than initial %d",
+			l.NodesExpanded(), initial)
+	}
+
+	// A repeated query with no edits needs no work at all
+	if _, err := l.FindPath(start, goal); err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+	if l.NodesExpanded() != 0 {
+		t.Errorf("Unchanged query expanded %d nodes, expected 0", l.NodesExpanded())
+	}
+}
+
+// TestLPAStar_NewQueryResetsState tests that changing endpoints starts over
+func TestLPAStar_NewQueryResetsState(t *testing.T) {
+	grid, _ := NewGrid(10, 10, FourWay)
+	l := NewLPAStar()
+	l.SetGrid(grid)
+
+	a, _ := grid.GetNode(0, 0)
+	b, _ := grid.GetNode(9, 9)
+	c, _ := grid.GetNode(9, 0)
+
+	if _, err := l.FindPath(a, b); err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+
+	path, err := l.FindPath(a, c)
+	if err != nil {
+		t.Fatalf("FindPath() returned error: %v", err)
+	}
+
+	if len(path) != 10 || !path[len(path)-1].Equals(c) {
+		t.Errorf("Expected 10-node path to new goal, got %d nodes ending at %v", len(path), path[len(path)-1])
+	}
+}
+
+// TestLPAStar_FindPath_InputValidation tests error handling for invalid inputs
+func TestLPAStar_FindPath_InputValidation(t *testing.T) {
+	l := NewLPAStar()
+	grid, _ := NewGrid(5, 5, FourWay)
+	node, _ := grid.GetNode(0, 0)
+
+	// Notifications before the first query are ignored
+	l.NotifyChanged(node)
+
+	if _, err := l.FindPath(node, node); err == nil {
+		t.Error("FindPath() should return error when grid is not set")
+	}
+
+	l.SetGrid(grid)
+	path, err := l.FindPath(node, node)
+	if err != nil || len(path) != 1 {
+		t.Errorf("Expected single-node path for same start/goal, got %v (err=%v)", path, err)
+	}
+
+	goal, _ := grid.GetNode(4, 2)
+	var wall []*Node
+	for y := 0; y < 5; y++ {
+		grid.SetObstacle(2, y)
+		n, _ := grid.GetNode(2, y)
+		wall = append(wall, n)
+	}
+	l.NotifyChanged(wall...)
+
+	if path, err := l.FindPath(node, goal); err == nil || path != nil {
+		t.Error("FindPath() should return nil path and error when no path exists")
+	}
+}
@@ -6,8 +6,11 @@ import "fmt"
 // It contains position coordinates, A* algorithm costs (g, h, f),
 // and parent tracking for path reconstruction.
 type Node struct {
-	// Position coordinates in the grid
-	X, Y int
+	// Position coordinates in the grid (Z is 0 except on 3D grids)
+	X, Y, Z int
+
+	// ID identifies the node in a Graph; grid nodes leave it at 0
+	ID int
 
 	// A* algorithm costs
 	G float64 // Cost from start node to this node
@@ -45,6 +48,22 @@ func NewNode(x, y int) *Node {
 	}
 }
 
+// NewNode3D creates a new node at the specified 3D coordinates.
+// By default, the node is not an obstacle and has a movement cost of 1.0.
+//
+// Params:
+//
+//	x, y, z: grid coordinates
+//
+// Returns:
+//
+//	*Node: new node instance
+func NewNode3D(x, y, z int) *Node {
+	node := NewNode(x, y)
+	node.Z = z
+	return node
+}
+
 // NewObstacle creates a new obstacle node at the specified coordinates.
 //
 // Params:
@@ -75,7 +94,7 @@ func (n *Node) Reset() {
 	n.Parent = nil
 }
 
-// Equals checks if two nodes have the same position coordinates.
+// Equals checks if two nodes have the same position coordinates and graph ID.
 // Used for comparing nodes in pathfinding algorithms.
 //
 // Params:
@@ -84,17 +103,22 @@ func (n *Node) Reset() {
 //
 // Returns:
 //
-//	bool: true if positions match
+//	bool: true if positions and IDs match
 func (n *Node) Equals(other *Node) bool {
 	if other == nil {
 		return false
 	}
-	return n.X == other.X && n.Y == other.Y
+	return n.ID == other.ID && n.X == other.X && n.Y == other.Y && n.Z == other.Z
 }
 
 // String returns a string representation of the node for debugging.
-// Format: "Node(x,y) [g=G, h=H, f=F] obstacle=IsObstacle"
+// Format: "Node(x,y) [g=G, h=H, f=F] obstacle=IsObstacle", with the
+// position written as (x,y,z) for nodes off the z = 0 plane.
 func (n *Node) String() string {
+	if n.Z != 0 {
+		return fmt.Sprintf("Node(%d,%d,%d) [g=%.2f, h=%.2f, f=%.2f] obstacle=%t",
+			n.X, n.Y, n.Z, n.G, n.H, n.F, n.IsObstacle)
+	}
 	return fmt.Sprintf("Node(%d,%d) [g=%.2f, h=%.2f, f=%.2f] obstacle=%t",
 		n.X, n.Y, n.G, n.H, n.F, n.IsObstacle)
 }
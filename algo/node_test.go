@@ -104,6 +104,10 @@ func TestNodeEquals(t *testing.T) {
 	if node1.Equals(nil) {
 		t.Error("Node should not equal nil")
 	}
+
+	if node1.Equals(NewNode3D(5, 10, 1)) {
+		t.Error("Nodes on different layers should not be equal")
+	}
 }
 
 func TestNodeString(t *testing.T) {
@@ -118,6 +122,12 @@ func TestNodeString(t *testing.T) {
 	if str != expected {
 		t.Errorf("Expected string %q, got %q", expected, str)
 	}
+
+	node.Z = 2
+	expected = "Node(5,10,2) [g=2.50, h=3.70, f=6.20] obstacle=false"
+	if str := node.String(); str != expected {
+		t.Errorf("Expected string %q, got %q", expected, str)
+	}
 }
 
 // Benchmark for node creation
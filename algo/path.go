@@ -0,0 +1,108 @@
+package algo
+
+import (
+	"fmt"
+)
+
+// SearchResult describes the outcome of a pathfinding operation in more
+// detail than the plain node slice returned by Pathfinder.FindPath.
+type SearchResult struct {
+	// Path from start to goal (including both endpoints)
+	Path []*Node
+
+	// Cost is the total movement cost of Path
+	Cost float64
+
+	// NodesExpanded counts nodes removed from the open set and expanded
+	NodesExpanded int
+
+	// Optimal reports whether the algorithm guarantees Path is a lowest-cost path
+	Optimal bool
+
+	// Bound is the guaranteed suboptimality factor: Cost <= Bound * optimal cost.
+	// It is 1 for optimal searches and +Inf when no bound exists.
+	Bound float64
+}
+
+// PathCost returns the total movement cost of a path on the given grid.
+// The cost is the sum of GetCost for each consecutive pair of nodes,
+// so paths produced by different pathfinders can be compared directly.
+//
+// Params:
+//
+//	grid: grid the path was computed on
+//	path: path from start to goal
+//
+// Returns:
+//
+//	float64: total movement cost (0 for empty or single-node paths)
+func PathCost(grid SearchSpace, path []*Node) float64 {
+	total := 0.0
+	for i := 1; i < len(path); i++ {
+		total += grid.GetCost(path[i-1], path[i])
+	}
+	return total
+}
+
+// validateEndpoints performs the input checks shared by all pathfinders.
+//
+// Params:
+//
+//	grid: configured search space
+//	start, goal: endpoints of the search
+//
+// Returns:
+//
+//	error: if any endpoint is nil or an obstacle, or grid is not set
+func validateEndpoints(grid SearchSpace, start, goal *Node) error {
+	if start == nil || goal == nil {
+		return fmt.Errorf("start and goal nodes cannot be nil")
+	}
+
+	if grid == nil {
+		return fmt.Errorf("grid must be set before calling FindPath")
+	}
+
+	if start.IsObstacle {
+		return fmt.Errorf("start node at (%d, %d) is an obstacle", start.X, start.Y)
+	}
+
+	if goal.IsObstacle {
+		return fmt.Errorf("goal node at (%d, %d) is an obstacle", goal.X, goal.Y)
+	}
+
+	return nil
+}
+
+// reconstructPath builds the final path by following parent pointers from goal to start.
+// Returns the path in forward order (start to goal).
+//
+// Params:
+//
+//	goalNode: the goal node with parent chain leading to start
+//
+// Returns:
+//
+//	[]*Node: path from start to goal
+func reconstructPath(goalNode *Node) []*Node {
+	var path []*Node
+	current := goalNode
+
+	// Follow parent chain from goal to start
+	for current != nil {
+		path = append(path, current)
+		current = current.Parent
+	}
+
+	// Reverse the path to get start -> goal order
+	reversePath(path)
+
+	return path
+}
+
+// reversePath reverses a path in place.
+func reversePath(path []*Node) {
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+}
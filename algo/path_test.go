@@ -0,0 +1,82 @@
+package algo
+
+import (
+	"testing"
+)
+
+// TestPathCost tests summing movement costs along a path
+func TestPathCost(t *testing.T) {
+	grid, _ := NewGrid(5, 5, EightWay)
+
+	a, _ := grid.GetNode(0, 0)
+	b, _ := grid.GetNode(1, 0)
+	c, _ := grid.GetNode(2, 1)
+	c.Cost = 2.0
+
+	tests := []struct {
+		name     string
+		path     []*Node
+		expected float64
+	}{
+		{"empty path", nil, 0},
+		{"single node", []*Node{a}, 0},
+		{"straight step", []*Node{a, b}, 1.0},
+		{"straight then weighted diagonal", []*Node{a, b, c}, 1.0 + 1.414*2.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PathCost(grid, tt.path); got != tt.expected {
+				t.Errorf("PathCost() = %.3f, expected %.3f", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestReconstructPath tests following parent pointers back to the start
+func TestReconstructPath(t *testing.T) {
+	a := NewNode(0, 0)
+	b := NewNode(1, 0)
+	c := NewNode(2, 0)
+	b.Parent = a
+	c.Parent = b
+
+	path := reconstructPath(c)
+
+	if len(path) != 3 {
+		t.Fatalf("Expected path length 3, got %d", len(path))
+	}
+
+	for i, expected := range []*Node{a, b, c} {
+		if path[i] != expected {
+			t.Errorf("path[%d] = %v, expected %v", i, path[i], expected)
+		}
+	}
+}
+
+// assertContiguousPath fails the test if any consecutive pair of path nodes
+// are not neighbors on the grid, or if the path crosses an obstacle
+func assertContiguousPath(t *testing.T, grid GridInterface, path []*Node) {
+	t.Helper()
+
+	for i, node := range path {
+		if node.IsObstacle {
+			t.Errorf("Path goes through obstacle at (%d, %d)", node.X, node.Y)
+		}
+		if i == 0 {
+			continue
+		}
+
+		adjacent := false
+		for _, neighbor := range grid.GetNeighbors(path[i-1]) {
+			if neighbor.Equals(node) {
+				adjacent = true
+				break
+			}
+		}
+		if !adjacent {
+			t.Errorf("Path step %d: (%d, %d) -> (%d, %d) is not a valid move",
+				i, path[i-1].X, path[i-1].Y, node.X, node.Y)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package algo
 
 import (
 	"container/heap"
+	"math"
 )
 
 // PriorityQueue implements a priority queue for A* pathfinding using Go's heap interface.
@@ -10,7 +11,7 @@ import (
 type PriorityQueue struct {
 	nodes []*Node
 	// nodeIndex maps node coordinates to their index in the heap for fast lookups
-	nodeIndex map[coordinate]int
+	nodeIndex map[nodeKey]int
 }
 
 // coordinate represents a position in the grid for efficient map lookups
@@ -18,6 +19,18 @@ type coordinate struct {
 	x, y int
 }
 
+// nodeKey identifies a node by its graph ID and all three coordinates, so
+// that queues and closed sets also work on 3D grids and graphs. On 2D grids
+// id and z are always 0.
+type nodeKey struct {
+	id, x, y, z int
+}
+
+// keyOf returns the lookup key of a node.
+func keyOf(node *Node) nodeKey {
+	return nodeKey{node.ID, node.X, node.Y, node.Z}
+}
+
 // NewPriorityQueue creates a new empty priority queue for A* pathfinding.
 //
 // Returns:
@@ -26,7 +39,7 @@ type coordinate struct {
 func NewPriorityQueue() *PriorityQueue {
 	pq := &PriorityQueue{
 		nodes:     make([]*Node, 0),
-		nodeIndex: make(map[coordinate]int),
+		nodeIndex: make(map[nodeKey]int),
 	}
 	heap.Init(pq)
 	return pq
@@ -51,15 +64,15 @@ func (pq *PriorityQueue) Swap(i, j int) {
 	pq.nodes[i], pq.nodes[j] = pq.nodes[j], pq.nodes[i]
 
 	// Update indices in the lookup map
-	pq.nodeIndex[coordinate{pq.nodes[i].X, pq.nodes[i].Y}] = i
-	pq.nodeIndex[coordinate{pq.nodes[j].X, pq.nodes[j].Y}] = j
+	pq.nodeIndex[keyOf(pq.nodes[i])] = i
+	pq.nodeIndex[keyOf(pq.nodes[j])] = j
 }
 
 // Push adds a new node to the priority queue.
 // This is required by the heap.Interface. Use PushNode instead for type safety.
 func (pq *PriorityQueue) Push(x interface{}) {
 	node := x.(*Node)
-	pq.nodeIndex[coordinate{node.X, node.Y}] = len(pq.nodes)
+	pq.nodeIndex[keyOf(node)] = len(pq.nodes)
 	pq.nodes = append(pq.nodes, node)
 }
 
@@ -73,7 +86,7 @@ func (pq *PriorityQueue) Pop() interface{} {
 
 	node := pq.nodes[n-1]
 	pq.nodes = pq.nodes[:n-1]
-	delete(pq.nodeIndex, coordinate{node.X, node.Y})
+	delete(pq.nodeIndex, keyOf(node))
 	return node
 }
 
@@ -85,10 +98,8 @@ func (pq *PriorityQueue) Pop() interface{} {
 //
 //	node: node to add to the queue
 func (pq *PriorityQueue) PushNode(node *Node) {
-	coord := coordinate{node.X, node.Y}
-
 	// Check if node already exists
-	if index, exists := pq.nodeIndex[coord]; exists {
+	if index, exists := pq.nodeIndex[keyOf(node)]; exists {
 		// Update existing node if new F cost is lower
 		existingNode := pq.nodes[index]
 		if node.F < existingNode.F {
@@ -117,7 +128,7 @@ func (pq *PriorityQueue) PopNode() *Node {
 	return heap.Pop(pq).(*Node)
 }
 
-// Contains checks if a node at the given coordinates exists in the queue.
+// Contains checks if a node at the given 2D coordinates exists in the queue.
 //
 // Params:
 //
@@ -127,7 +138,7 @@ func (pq *PriorityQueue) PopNode() *Node {
 //
 //	bool: true if a node at these coordinates is in the queue
 func (pq *PriorityQueue) Contains(x, y int) bool {
-	_, exists := pq.nodeIndex[coordinate{x, y}]
+	_, exists := pq.nodeIndex[nodeKey{x: x, y: y}]
 	return exists
 }
 
@@ -141,10 +152,11 @@ func (pq *PriorityQueue) Contains(x, y int) bool {
 //
 //	bool: true if the node is in the queue
 func (pq *PriorityQueue) ContainsNode(node *Node) bool {
-	return pq.Contains(node.X, node.Y)
+	_, exists := pq.nodeIndex[keyOf(node)]
+	return exists
 }
 
-// GetNode retrieves a node at the given coordinates from the queue.
+// GetNode retrieves a node at the given 2D coordinates from the queue.
 // Returns nil if no node exists at those coordinates.
 //
 // Params:
@@ -155,7 +167,7 @@ func (pq *PriorityQueue) ContainsNode(node *Node) bool {
 //
 //	*Node: node at the coordinates, or nil if not found
 func (pq *PriorityQueue) GetNode(x, y int) *Node {
-	if index, exists := pq.nodeIndex[coordinate{x, y}]; exists {
+	if index, exists := pq.nodeIndex[nodeKey{x: x, y: y}]; exists {
 		return pq.nodes[index]
 	}
 	return nil
@@ -177,7 +189,7 @@ func (pq *PriorityQueue) Peek() *Node {
 // Clear removes all nodes from the priority queue.
 func (pq *PriorityQueue) Clear() {
 	pq.nodes = pq.nodes[:0]
-	pq.nodeIndex = make(map[coordinate]int)
+	pq.nodeIndex = make(map[nodeKey]int)
 }
 
 // IsEmpty returns true if the priority queue contains no nodes.
@@ -190,7 +202,8 @@ func (pq *PriorityQueue) IsEmpty() bool {
 }
 
 // UpdatePriority updates the F cost of a node already in the queue
-// and re-heapifies to maintain ordering.
+// and re-heapifies to maintain ordering. The node is looked up by its 2D
+// coordinates; use UpdateNodePriority for nodes of 3D grids.
 //
 // Params:
 //
@@ -201,10 +214,96 @@ func (pq *PriorityQueue) IsEmpty() bool {
 //
 //	bool: true if node was found and updated
 func (pq *PriorityQueue) UpdatePriority(x, y int, newF float64) bool {
-	if index, exists := pq.nodeIndex[coordinate{x, y}]; exists {
+	return pq.updateIndex(nodeKey{x: x, y: y}, newF)
+}
+
+// UpdateNodePriority updates the F cost of a node already in the queue,
+// matching it on its ID and all three coordinates.
+//
+// Params:
+//
+//	node: node to update
+//	newF: new F cost for the node
+//
+// Returns:
+//
+//	bool: true if node was found and updated
+func (pq *PriorityQueue) UpdateNodePriority(node *Node, newF float64) bool {
+	return pq.updateIndex(keyOf(node), newF)
+}
+
+// updateIndex sets the F cost of the queued node with the given key.
+func (pq *PriorityQueue) updateIndex(key nodeKey, newF float64) bool {
+	if index, exists := pq.nodeIndex[key]; exists {
 		pq.nodes[index].F = newF
 		heap.Fix(pq, index)
 		return true
 	}
 	return false
 }
+
+// queueEntry pairs a node with a priority that is independent of the node's F cost.
+// Entries with equal priority are ordered by ascending tieBreak.
+type queueEntry struct {
+	node     *Node
+	priority float64
+	tieBreak float64
+}
+
+// entryQueue is a min-heap of (node, priority) entries.
+// Unlike PriorityQueue it never reads Node.F and allows the same node to be
+// queued several times, so multiple searches can order the same nodes
+// independently. Callers skip outdated entries when popping (lazy deletion).
+type entryQueue struct {
+	entries []queueEntry
+}
+
+// Len returns the number of entries in the queue (heap.Interface).
+func (q *entryQueue) Len() int { return len(q.entries) }
+
+// Less orders entries by ascending priority (heap.Interface).
+func (q *entryQueue) Less(i, j int) bool {
+	if q.entries[i].priority != q.entries[j].priority {
+		return q.entries[i].priority < q.entries[j].priority
+	}
+	return q.entries[i].tieBreak < q.entries[j].tieBreak
+}
+
+// Swap exchanges two entries (heap.Interface).
+func (q *entryQueue) Swap(i, j int) { q.entries[i], q.entries[j] = q.entries[j], q.entries[i] }
+
+// Push appends an entry (heap.Interface). Use push instead.
+func (q *entryQueue) Push(x interface{}) { q.entries = append(q.entries, x.(queueEntry)) }
+
+// Pop removes the last entry (heap.Interface). Use pop instead.
+func (q *entryQueue) Pop() interface{} {
+	n := len(q.entries)
+	entry := q.entries[n-1]
+	q.entries = q.entries[:n-1]
+	return entry
+}
+
+// push adds a node with the given priority and tie-breaking key.
+// Searches typically pass -g as tieBreak to prefer deeper nodes among equal f-costs.
+func (q *entryQueue) push(node *Node, priority, tieBreak float64) {
+	heap.Push(q, queueEntry{node: node, priority: priority, tieBreak: tieBreak})
+}
+
+// pop removes and returns the entry with the lowest priority.
+// The queue must not be empty.
+func (q *entryQueue) pop() queueEntry {
+	return heap.Pop(q).(queueEntry)
+}
+
+// peekPriority returns the lowest priority in the queue, or +Inf if empty.
+func (q *entryQueue) peekPriority() float64 {
+	if len(q.entries) == 0 {
+		return math.Inf(1)
+	}
+	return q.entries[0].priority
+}
+
+// clear removes all entries while keeping the allocated capacity.
+func (q *entryQueue) clear() {
+	q.entries = q.entries[:0]
+}
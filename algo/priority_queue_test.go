@@ -2,6 +2,7 @@ package algo
 
 import (
 	"container/heap"
+	"math"
 	"testing"
 )
 
@@ -226,6 +227,35 @@ func TestPriorityQueueUpdatePriority(t *testing.T) {
 	}
 }
 
+func TestPriorityQueueNodesIn3D(t *testing.T) {
+	pq := NewPriorityQueue()
+
+	// Same X and Y on different layers are different nodes
+	lower := NewNode3D(1, 1, 0)
+	lower.F = 10.0
+	upper := NewNode3D(1, 1, 1)
+	upper.F = 8.0
+
+	pq.PushNode(lower)
+	pq.PushNode(upper)
+	if pq.Len() != 2 {
+		t.Fatalf("Expected 2 nodes, got %d", pq.Len())
+	}
+	if !pq.ContainsNode(upper) || pq.GetNode(1, 1) != lower {
+		t.Error("Nodes should be looked up by all three coordinates")
+	}
+
+	if !pq.UpdateNodePriority(lower, 3.0) {
+		t.Error("UpdateNodePriority should return true for existing node")
+	}
+	if peeked := pq.Peek(); peeked != lower {
+		t.Errorf("Updated node should come first, got %v", peeked)
+	}
+	if pq.UpdateNodePriority(NewNode3D(1, 1, 2), 1.0) {
+		t.Error("UpdateNodePriority should return false for non-existent node")
+	}
+}
+
 func TestPriorityQueueClear(t *testing.T) {
 	pq := NewPriorityQueue()
 
@@ -399,3 +429,55 @@ func BenchmarkPriorityQueueContains(b *testing.B) {
 		pq.Contains(i%100, (i/100)%10)
 	}
 }
+
+func TestEntryQueue(t *testing.T) {
+	q := &entryQueue{}
+
+	if !math.IsInf(q.peekPriority(), 1) {
+		t.Errorf("Empty queue should peek +Inf, got %f", q.peekPriority())
+	}
+
+	node := NewNode(1, 1)
+	other := NewNode(2, 2)
+
+	// The same node may be queued several times with different priorities
+	q.push(node, 5.0, 0)
+	q.push(other, 3.0, 0)
+	q.push(node, 1.0, 0)
+
+	if q.Len() != 3 {
+		t.Fatalf("Expected 3 entries, got %d", q.Len())
+	}
+
+	if q.peekPriority() != 1.0 {
+		t.Errorf("Expected lowest priority 1.0, got %f", q.peekPriority())
+	}
+
+	expected := []struct {
+		node     *Node
+		priority float64
+	}{
+		{node, 1.0},
+		{other, 3.0},
+		{node, 5.0},
+	}
+	for i, want := range expected {
+		entry := q.pop()
+		if entry.node != want.node || entry.priority != want.priority {
+			t.Errorf("pop %d = (%v, %f), expected (%v, %f)", i, entry.node, entry.priority, want.node, want.priority)
+		}
+	}
+
+	// Equal priorities fall back to the tie-breaking key
+	q.push(node, 2.0, 0)
+	q.push(other, 2.0, -1)
+	if entry := q.pop(); entry.node != other {
+		t.Errorf("Expected lower tie-break entry first, got %v", entry.node)
+	}
+
+	q.push(node, 2.0, 0)
+	q.clear()
+	if q.Len() != 0 {
+		t.Errorf("Expected empty queue after clear, got %d entries", q.Len())
+	}
+}
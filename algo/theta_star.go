@@ -0,0 +1,261 @@
+package algo
+
+import (
+	"fmt"
+	"math"
+)
+
+// lineOfSightGrid is implemented by search spaces that can test whether a
+// straight line between two cells is unobstructed (e.g. Grid).
+type lineOfSightGrid interface {
+	SearchSpace
+	LineOfSight(x0, y0, x1, y1 int) bool
+}
+
+// ThetaStar implements Theta* any-angle pathfinding.
+// Theta* works like A*, but when relaxing a neighbor it first checks whether
+// the current node's parent can see the neighbor directly. If so, the
+// neighbor is connected to that parent instead, skipping the current node.
+// The resulting paths are not restricted to 45° steps and look far more
+// natural than grid paths.
+//
+// Path costs are Euclidean distances between waypoints; Node.Cost terrain
+// weights are not taken into account. The returned path contains only the
+// turning points (waypoints), so consecutive nodes are generally not grid
+// neighbors. The search space must provide line-of-sight checks, as Grid
+// does through Grid.LineOfSight.
+//
+// The Lazy Theta* variant (NewLazyThetaStar) optimistically assumes line of
+// sight when a neighbor is generated and only verifies it when the neighbor
+// is expanded, which performs far fewer line-of-sight checks.
+type ThetaStar struct {
+	// grid is the search space we're pathfinding within
+	grid SearchSpace
+
+	// heuristic function estimates cost from current node to goal
+	heuristic HeuristicFunc
+
+	// lazy enables the Lazy Theta* variant
+	lazy bool
+
+	// openSet contains nodes to be evaluated, ordered by f-cost
+	openSet *PriorityQueue
+
+	// closedSet contains nodes already evaluated (coordinates -> bool)
+	closedSet map[coordinate]bool
+
+	// losChecks counts line-of-sight checks during the last search
+	losChecks int
+}
+
+// NewThetaStar creates a new Theta* pathfinder instance.
+// By default, it uses the Euclidean heuristic, which matches its path costs.
+//
+// Returns:
+//
+//	*ThetaStar: new Theta* pathfinder instance
+func NewThetaStar() *ThetaStar {
+	return &ThetaStar{
+		heuristic: Euclidean,
+		openSet:   NewPriorityQueue(),
+		closedSet: make(map[coordinate]bool),
+	}
+}
+
+// NewLazyThetaStar creates a new Lazy Theta* pathfinder instance.
+// Lazy Theta* defers line-of-sight checks until a node is expanded,
+// producing paths of similar quality with fewer checks.
+//
+// Returns:
+//
+//	*ThetaStar: new Lazy Theta* pathfinder instance
+func NewLazyThetaStar() *ThetaStar {
+	t := NewThetaStar()
+	t.lazy = true
+	return t
+}
+
+// SetGrid configures the grid/search space for the pathfinder.
+// The grid must support line-of-sight checks; this is checked in FindPath.
+//
+// Params:
+//
+//	grid: grid to search within
+func (t *ThetaStar) SetGrid(grid SearchSpace) {
+	t.grid = grid
+}
+
+// SetHeuristic configures the heuristic function for the pathfinder.
+// Euclidean is recommended since path costs are straight-line distances.
+//
+// Params:
+//
+//	heuristic: heuristic function to use
+func (t *ThetaStar) SetHeuristic(heuristic HeuristicFunc) {
+	t.heuristic = heuristic
+}
+
+// LineOfSightChecks returns the number of line-of-sight checks performed
+// during the last search. Useful for comparing Theta* and Lazy Theta*.
+//
+// Returns:
+//
+//	int: number of line-of-sight checks
+func (t *ThetaStar) LineOfSightChecks() int {
+	return t.losChecks
+}
+
+// FindPath finds an any-angle path from start to goal.
+// The path contains only waypoints: start, each turning point, and goal.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	[]*Node: waypoints from start to goal (including both endpoints)
+//	error: if no path exists, the grid lacks line-of-sight support, or invalid input
+func (t *ThetaStar) FindPath(start, goal *Node) ([]*Node, error) {
+	result, err := t.Search(start, goal)
+	if err != nil {
+		return nil, err
+	}
+	return result.Path, nil
+}
+
+// Search runs Theta* (or Lazy Theta*) and returns a detailed result.
+// Cost is the Euclidean length of the waypoint path. Any-angle paths are
+// not guaranteed to be the true shortest paths, so Optimal is false.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	*SearchResult: waypoint path, its length and search statistics
+//	error: if no path exists, the grid lacks line-of-sight support, or invalid input
+func (t *ThetaStar) Search(start, goal *Node) (*SearchResult, error) {
+	if err := validateEndpoints(t.grid, start, goal); err != nil {
+		return nil, err
+	}
+
+	if t.heuristic == nil {
+		return nil, fmt.Errorf("heuristic must be set before calling FindPath")
+	}
+
+	grid, ok := t.grid.(lineOfSightGrid)
+	if !ok {
+		return nil, fmt.Errorf("Theta* requires a grid with line-of-sight support, got %T", t.grid)
+	}
+
+	t.losChecks = 0
+	if start.Equals(goal) {
+		return &SearchResult{Path: []*Node{start}, Bound: math.Inf(1)}, nil
+	}
+
+	t.reset()
+
+	start.G = 0
+	start.H = t.heuristic(start, goal)
+	start.CalculateF()
+	start.Parent = nil
+	t.openSet.PushNode(start)
+
+	expanded := 0
+	for !t.openSet.IsEmpty() {
+		current := t.openSet.PopNode()
+
+		if t.lazy {
+			t.setVertex(grid, current)
+		}
+
+		if current.Equals(goal) {
+			return &SearchResult{
+				Path:          reconstructPath(current),
+				Cost:          current.G,
+				NodesExpanded: expanded,
+				Bound:         math.Inf(1),
+			}, nil
+		}
+
+		t.closedSet[coordinate{current.X, current.Y}] = true
+		expanded++
+
+		for _, neighbor := range grid.GetNeighbors(current) {
+			if neighbor.IsObstacle || t.closedSet[coordinate{neighbor.X, neighbor.Y}] {
+				continue
+			}
+
+			// Path 2: connect neighbor straight to current's parent when visible.
+			// Lazy Theta* assumes visibility here and verifies it in setVertex.
+			parent := current
+			if current.Parent != nil && (t.lazy || t.lineOfSight(grid, current.Parent, neighbor)) {
+				parent = current.Parent
+			}
+
+			newG := parent.G + Euclidean(parent, neighbor)
+			inOpenSet := t.openSet.ContainsNode(neighbor)
+
+			if !inOpenSet || newG < neighbor.G {
+				neighbor.G = newG
+				neighbor.H = t.heuristic(neighbor, goal)
+				neighbor.CalculateF()
+				neighbor.Parent = parent
+
+				if !inOpenSet {
+					t.openSet.PushNode(neighbor)
+				} else {
+					t.openSet.UpdatePriority(neighbor.X, neighbor.Y, neighbor.F)
+				}
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+		start.X, start.Y, goal.X, goal.Y)
+}
+
+// setVertex verifies the optimistic parent assigned by Lazy Theta*.
+// If the parent cannot actually see the node, the node is re-attached to
+// the best already-expanded neighbor (a standard grid connection).
+//
+// Params:
+//
+//	grid: grid being searched
+//	node: node about to be expanded
+func (t *ThetaStar) setVertex(grid lineOfSightGrid, node *Node) {
+	if node.Parent == nil || t.lineOfSight(grid, node.Parent, node) {
+		return
+	}
+
+	node.G = math.Inf(1)
+	for _, neighbor := range grid.GetNeighbors(node) {
+		if !t.closedSet[coordinate{neighbor.X, neighbor.Y}] {
+			continue
+		}
+		if g := neighbor.G + Euclidean(neighbor, node); g < node.G {
+			node.G = g
+			node.Parent = neighbor
+		}
+	}
+	node.CalculateF()
+}
+
+// lineOfSight checks visibility between two nodes and counts the check.
+func (t *ThetaStar) lineOfSight(grid lineOfSightGrid, from, to *Node) bool {
+	t.losChecks++
+	return grid.LineOfSight(from.X, from.Y, to.X, to.Y)
+}
+
+// reset clears the algorithm state for a fresh pathfinding operation.
+func (t *ThetaStar) reset() {
+	t.openSet.Clear()
+	t.closedSet = make(map[coordinate]bool)
+	if t.grid != nil {
+		t.grid.Reset()
+	}
+}
@@ -0,0 +1,188 @@
+package algo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// waypointLength returns the Euclidean length of a waypoint path
+func waypointLength(path []*Node) float64 {
+	total := 0.0
+	for i := 1; i < len(path); i++ {
+		total += Euclidean(path[i-1], path[i])
+	}
+	return total
+}
+
+// TestThetaStar_ImplementsPathfinder verifies interface compliance
+func TestThetaStar_ImplementsPathfinder(t *testing.T) {
+	var _ Pathfinder = NewThetaStar()
+	var _ Pathfinder = NewLazyThetaStar()
+}
+
+// TestThetaStar_FindPath_AnyAngle tests that an open grid yields a single
+// straight segment at a non-45° angle
+func TestThetaStar_FindPath_AnyAngle(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ts   *ThetaStar
+	}{
+		{"Theta*", NewThetaStar()},
+		{"Lazy Theta*", NewLazyThetaStar()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			grid, _ := NewGrid(10, 10, EightWay)
+			tc.ts.SetGrid(grid)
+
+			start, _ := grid.GetNode(0, 0)
+			goal, _ := grid.GetNode(9, 4)
+
+			result, err := tc.ts.Search(start, goal)
+			if err != nil {
+				t.Fatalf("Search() returned error: %v", err)
+			}
+
+			if len(result.Path) != 2 {
+				t.Errorf("Expected direct start-goal path, got %d waypoints", len(result.Path))
+			}
+
+			if math.Abs(result.Cost-Euclidean(start, goal)) > 1e-9 {
+				t.Errorf("Expected cost %.4f, got %.4f", Euclidean(start, goal), result.Cost)
+			}
+
+			if result.Optimal {
+				t.Error("Theta* results should not be reported optimal")
+			}
+		})
+	}
+}
+
+// TestThetaStar_FindPath_AroundObstacles tests that every waypoint segment
+// is visible and that paths are no longer than 8-way grid paths
+func TestThetaStar_FindPath_AroundObstacles(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+
+	for trial := 0; trial < 20; trial++ {
+		grid, _ := NewGrid(30, 30, EightWay)
+		for x := 0; x < 30; x++ {
+			for y := 0; y < 30; y++ {
+				if rng.Float64() < 0.2 {
+					grid.SetObstacle(x, y)
+				}
+			}
+		}
+
+		start, _ := grid.GetNode(0, 0)
+		goal, _ := grid.GetNode(29, 29)
+		start.IsObstacle = false
+		goal.IsObstacle = false
+
+		astar := NewAStar()
+		astar.SetGrid(grid)
+		astar.SetHeuristic(Diagonal)
+		gridPath, aErr := astar.FindPath(start, goal)
+		var gridLength float64
+		if aErr == nil {
+			gridLength = waypointLength(gridPath)
+		}
+
+		for _, ts := range []*ThetaStar{NewThetaStar(), NewLazyThetaStar()} {
+			ts.SetGrid(grid)
+			result, err := ts.Search(start, goal)
+
+			if (aErr == nil) != (err == nil) {
+				t.Fatalf("trial %d: A* err=%v, Theta* (lazy=%v) err=%v", trial, aErr, ts.lazy, err)
+			}
+			if err != nil {
+				continue
+			}
+
+			for i := 1; i < len(result.Path); i++ {
+				a, b := result.Path[i-1], result.Path[i]
+				if !grid.LineOfSight(a.X, a.Y, b.X, b.Y) {
+					t.Errorf("trial %d (lazy=%v): no line of sight between (%d,%d) and (%d,%d)",
+						trial, ts.lazy, a.X, a.Y, b.X, b.Y)
+				}
+			}
+
+			if math.Abs(waypointLength(result.Path)-result.Cost) > 1e-9 {
+				t.Errorf("trial %d (lazy=%v): reported cost %.4f does not match path length %.4f",
+					trial, ts.lazy, result.Cost, waypointLength(result.Path))
+			}
+
+			if result.Cost > gridLength+1e-9 {
+				t.Errorf("trial %d (lazy=%v): any-angle length %.4f exceeds grid path length %.4f",
+					trial, ts.lazy, result.Cost, gridLength)
+			}
+		}
+	}
+}
+
+// TestThetaStar_LazyFewerChecks tests that Lazy Theta* defers line-of-sight checks
+func TestThetaStar_LazyFewerChecks(t *testing.T) {
+	grid, _ := NewGrid(40, 40, EightWay)
+	for y := 5; y < 35; y++ {
+		grid.SetObstacle(20, y)
+	}
+
+	start, _ := grid.GetNode(2, 20)
+	goal, _ := grid.GetNode(38, 20)
+
+	eager := NewThetaStar()
+	eager.SetGrid(grid)
+	if _, err := eager.FindPath(start, goal); err != nil {
+		t.Fatalf("Theta* FindPath() returned error: %v", err)
+	}
+
+	lazy := NewLazyThetaStar()
+	lazy.SetGrid(grid)
+	if _, err := lazy.FindPath(start, goal); err != nil {
+		t.Fatalf("Lazy Theta* FindPath() returned error: %v", err)
+	}
+
+	if lazy.LineOfSightChecks() >= eager.LineOfSightChecks() {
+		t.Errorf("Lazy Theta* made %d line-of-sight checks, expected fewer than Theta*'s %d",
+			lazy.LineOfSightChecks(), eager.LineOfSightChecks())
+	}
+}
+
+// unsupportedGrid wraps a Grid but hides its LineOfSight method
+type unsupportedGrid struct {
+	GridInterface
+}
+
+// TestThetaStar_FindPath_InputValidation tests error handling for invalid inputs
+func TestThetaStar_FindPath_InputValidation(t *testing.T) {
+	ts := NewThetaStar()
+	grid, _ := NewGrid(5, 5, EightWay)
+	node, _ := grid.GetNode(0, 0)
+	other, _ := grid.GetNode(3, 1)
+
+	if _, err := ts.FindPath(nil, node); err == nil {
+		t.Error("FindPath() should return error for nil start node")
+	}
+
+	if _, err := ts.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when grid is not set")
+	}
+
+	ts.SetGrid(unsupportedGrid{grid})
+	if _, err := ts.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error for grids without line-of-sight support")
+	}
+
+	ts.SetGrid(grid)
+	ts.SetHeuristic(nil)
+	if _, err := ts.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when heuristic is nil")
+	}
+
+	ts.SetHeuristic(Euclidean)
+	for y := 0; y < 5; y++ {
+		grid.SetObstacle(2, y)
+	}
+	if path, err := ts.FindPath(node, other); err == nil || path != nil {
+		t.Error("FindPath() should return nil path and error when no path exists")
+	}
+}
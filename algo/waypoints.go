@@ -0,0 +1,349 @@
+package algo
+
+import (
+	"fmt"
+	"math"
+)
+
+// maxExactWaypoints caps the exact solver, whose memory grows as 2^n * n.
+const maxExactWaypoints = 16
+
+// Route is a path that visits a set of waypoints.
+type Route struct {
+	// Path from start through every waypoint (to the end, if given)
+	Path []*Node
+
+	// Order lists indices into the waypoints slice in visiting order
+	Order []int
+
+	// Cost is the sum of the costs of the stitched legs
+	Cost float64
+
+	// Exact reports whether the visiting order was solved exactly rather
+	// than heuristically
+	Exact bool
+}
+
+// searcher is implemented by pathfinders that report path costs directly,
+// which is required for any-angle paths whose consecutive nodes are not
+// neighbors.
+type searcher interface {
+	Search(start, goal *Node) (*SearchResult, error)
+}
+
+// WaypointRouter plans a path from a start through an unordered set of
+// waypoints, optionally finishing at a fixed end. This is the open
+// travelling salesman problem over grid paths.
+//
+// The configured Pathfinder computes a path between every ordered pair of
+// points; costs may be asymmetric, as they are on grids with per-cell
+// costs. The visiting order is then solved exactly with Held-Karp dynamic
+// programming when there are few waypoints (O(2^n * n^2)), and otherwise
+// with a nearest-neighbor tour improved by 2-opt until no reversal of a
+// sub-sequence lowers the cost. Finally the legs are stitched into one path.
+type WaypointRouter struct {
+	// grid is the search space we're pathfinding within
+	grid SearchSpace
+
+	// pathfinder computes the legs between points
+	pathfinder Pathfinder
+
+	// exactLimit is the largest waypoint count solved exactly
+	exactLimit int
+}
+
+// NewWaypointRouter creates a new waypoint router.
+// By default, it computes legs with A* and solves up to 12 waypoints exactly.
+//
+// Returns:
+//
+//	*WaypointRouter: new waypoint router
+func NewWaypointRouter() *WaypointRouter {
+	return &WaypointRouter{
+		pathfinder: NewAStar(),
+		exactLimit: 12,
+	}
+}
+
+// SetGrid configures the grid/search space for the router and its pathfinder.
+//
+// Params:
+//
+//	grid: grid to search within
+func (r *WaypointRouter) SetGrid(grid SearchSpace) {
+	r.grid = grid
+	if r.pathfinder != nil {
+		r.pathfinder.SetGrid(grid)
+	}
+}
+
+// SetPathfinder configures the pathfinder used for the legs between points.
+// It is attached to the router's grid if one is set.
+//
+// Params:
+//
+//	pathfinder: pathfinder to use
+func (r *WaypointRouter) SetPathfinder(pathfinder Pathfinder) {
+	r.pathfinder = pathfinder
+	if pathfinder != nil && r.grid != nil {
+		pathfinder.SetGrid(r.grid)
+	}
+}
+
+// SetExactLimit configures the largest number of waypoints whose visiting
+// order is solved exactly. Larger sets use the 2-opt heuristic.
+//
+// Params:
+//
+//	n: waypoint limit in [0, 16]
+//
+// Returns:
+//
+//	error: if n is outside [0, 16]
+func (r *WaypointRouter) SetExactLimit(n int) error {
+	if n < 0 || n > maxExactWaypoints {
+		return fmt.Errorf("exact limit must be in [0, %d], got %d", maxExactWaypoints, n)
+	}
+	r.exactLimit = n
+	return nil
+}
+
+// Route finds a short path from start that visits every waypoint once, in
+// any order, and then continues to end if end is not nil.
+//
+// Params:
+//
+//	start: starting node
+//	waypoints: nodes to visit, in no particular order
+//	end: final node, or nil to finish at the last waypoint
+//
+// Returns:
+//
+//	*Route: stitched path, visiting order and cost
+//	error: if some waypoint or the end cannot be reached, or invalid input
+func (r *WaypointRouter) Route(start *Node, waypoints []*Node, end *Node) (*Route, error) {
+	if r.pathfinder == nil {
+		return nil, fmt.Errorf("pathfinder must be set before calling Route")
+	}
+
+	// Points are the start, the waypoints, then the end if given
+	points := append([]*Node{start}, waypoints...)
+	if end != nil {
+		points = append(points, end)
+	}
+	for _, point := range points {
+		if err := validateEndpoints(r.grid, start, point); err != nil {
+			return nil, err
+		}
+	}
+
+	legs, costs := r.legs(points, len(waypoints), end != nil)
+
+	var order []int
+	exact := len(waypoints) <= r.exactLimit
+	if exact {
+		order = solveExactOrder(costs, len(waypoints), end != nil)
+	} else {
+		order = solveHeuristicOrder(costs, len(waypoints), end != nil)
+	}
+
+	// Walk the order as point indices and stitch the legs
+	sequence := make([]int, 0, len(points))
+	sequence = append(sequence, 0)
+	for _, w := range order {
+		sequence = append(sequence, w+1)
+	}
+	if end != nil {
+		sequence = append(sequence, len(points)-1)
+	}
+
+	route := &Route{Path: []*Node{start}, Order: order, Exact: exact}
+	for i := 1; i < len(sequence); i++ {
+		from, to := sequence[i-1], sequence[i]
+		if math.IsInf(costs[from][to], 1) {
+			return nil, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+				points[from].X, points[from].Y, points[to].X, points[to].Y)
+		}
+		route.Path = append(route.Path, legs[from][to][1:]...)
+		route.Cost += costs[from][to]
+	}
+
+	return route, nil
+}
+
+// legs computes the path and cost between every ordered pair of points
+// the route may use. Unreachable pairs cost +Inf.
+func (r *WaypointRouter) legs(points []*Node, n int, hasEnd bool) ([][][]*Node, [][]float64) {
+	legs := make([][][]*Node, len(points))
+	costs := make([][]float64, len(points))
+	for i := range points {
+		legs[i] = make([][]*Node, len(points))
+		costs[i] = make([]float64, len(points))
+		for j := range points {
+			costs[i][j] = math.Inf(1)
+		}
+	}
+
+	for i := 0; i <= n; i++ {
+		for j := 1; j < len(points); j++ {
+			if i == j || (i == 0 && hasEnd && j == len(points)-1 && n > 0) {
+				continue
+			}
+			legs[i][j], costs[i][j] = r.leg(points[i], points[j])
+		}
+	}
+	return legs, costs
+}
+
+// leg runs the pathfinder between two points.
+func (r *WaypointRouter) leg(from, to *Node) ([]*Node, float64) {
+	if from.Equals(to) {
+		return []*Node{from}, 0
+	}
+
+	if s, ok := r.pathfinder.(searcher); ok {
+		result, err := s.Search(from, to)
+		if err != nil {
+			return nil, math.Inf(1)
+		}
+		return result.Path, result.Cost
+	}
+
+	path, err := r.pathfinder.FindPath(from, to)
+	if err != nil {
+		return nil, math.Inf(1)
+	}
+	return path, PathCost(r.grid, path)
+}
+
+// routeCost returns the cost of visiting waypoints in order, where costs
+// is indexed by point (0 = start, w+1 = waypoint w, n+1 = end).
+func routeCost(costs [][]float64, order []int, hasEnd bool) float64 {
+	total := 0.0
+	prev := 0
+	for _, w := range order {
+		total += costs[prev][w+1]
+		prev = w + 1
+	}
+	if hasEnd {
+		total += costs[prev][len(order)+1]
+	}
+	return total
+}
+
+// solveExactOrder finds the cheapest visiting order with Held-Karp dynamic
+// programming over subsets of waypoints.
+func solveExactOrder(costs [][]float64, n int, hasEnd bool) []int {
+	if n == 0 {
+		return []int{}
+	}
+
+	full := 1<<n - 1
+
+	// best[mask][last] is the cheapest cost of starting at the start,
+	// visiting exactly the waypoints in mask and stopping at last
+	best := make([][]float64, full+1)
+	parent := make([][]int, full+1)
+	for mask := range best {
+		best[mask] = make([]float64, n)
+		parent[mask] = make([]int, n)
+		for i := range best[mask] {
+			best[mask][i] = math.Inf(1)
+		}
+	}
+	for i := 0; i < n; i++ {
+		best[1<<i][i] = costs[0][i+1]
+		parent[1<<i][i] = -1
+	}
+
+	for mask := 1; mask <= full; mask++ {
+		for last := 0; last < n; last++ {
+			if mask&(1<<last) == 0 || math.IsInf(best[mask][last], 1) {
+				continue
+			}
+			for next := 0; next < n; next++ {
+				if mask&(1<<next) != 0 {
+					continue
+				}
+				grown := mask | 1<<next
+				cost := best[mask][last] + costs[last+1][next+1]
+				if cost < best[grown][next] {
+					best[grown][next] = cost
+					parent[grown][next] = last
+				}
+			}
+		}
+	}
+
+	last, lowest := 0, math.Inf(1)
+	for i := 0; i < n; i++ {
+		cost := best[full][i]
+		if hasEnd {
+			cost += costs[i+1][n+1]
+		}
+		if cost < lowest {
+			last, lowest = i, cost
+		}
+	}
+
+	order := make([]int, n)
+	if math.IsInf(lowest, 1) {
+		// Every order has an unreachable leg; any one reports it
+		for i := range order {
+			order[i] = i
+		}
+		return order
+	}
+	for mask, i := full, n-1; i >= 0; i-- {
+		order[i] = last
+		prev := parent[mask][last]
+		mask &^= 1 << last
+		last = prev
+	}
+	return order
+}
+
+// solveHeuristicOrder builds a nearest-neighbor order and improves it with
+// 2-opt moves (reversing a sub-sequence) until no move lowers the cost.
+func solveHeuristicOrder(costs [][]float64, n int, hasEnd bool) []int {
+	order := make([]int, 0, n)
+	visited := make([]bool, n)
+	current := 0
+	for len(order) < n {
+		next := -1
+		for w := 0; w < n; w++ {
+			if !visited[w] && (next < 0 || costs[current][w+1] < costs[current][next+1]) {
+				next = w
+			}
+		}
+		visited[next] = true
+		order = append(order, next)
+		current = next + 1
+	}
+
+	cost := routeCost(costs, order, hasEnd)
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < n-1; i++ {
+			for j := i + 1; j < n; j++ {
+				reverseInts(order[i : j+1])
+				// Costs may be asymmetric, so the reversed segment is
+				// re-evaluated in full
+				if candidate := routeCost(costs, order, hasEnd); candidate < cost-1e-9 {
+					cost = candidate
+					improved = true
+				} else {
+					reverseInts(order[i : j+1])
+				}
+			}
+		}
+	}
+	return order
+}
+
+// reverseInts reverses a slice in place.
+func reverseInts(s []int) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
@@ -0,0 +1,262 @@
+package algo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// bruteForceRouteCost tries every visiting order (only feasible for few waypoints)
+func bruteForceRouteCost(costs [][]float64, n int, hasEnd bool) float64 {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+
+	best := math.Inf(1)
+	var permute func(k int)
+	permute = func(k int) {
+		if k == n {
+			best = math.Min(best, routeCost(costs, order, hasEnd))
+			return
+		}
+		for i := k; i < n; i++ {
+			order[k], order[i] = order[i], order[k]
+			permute(k + 1)
+			order[k], order[i] = order[i], order[k]
+		}
+	}
+	permute(0)
+	return best
+}
+
+// newWaypointTestGrid creates a weighted grid with scattered obstacles
+func newWaypointTestGrid(rng *rand.Rand) *Grid {
+	grid, _ := NewGrid(15, 15, EightWay)
+	for y := 0; y < 15; y++ {
+		for x := 0; x < 15; x++ {
+			node, _ := grid.GetNode(x, y)
+			node.Cost = 1 + float64(rng.Intn(3))
+			if rng.Float64() < 0.15 {
+				grid.SetObstacle(x, y)
+			}
+		}
+	}
+	return grid
+}
+
+// randomFreeNodes picks n distinct walkable nodes
+func randomFreeNodes(rng *rand.Rand, grid *Grid, n int) []*Node {
+	var nodes []*Node
+	seen := make(map[coordinate]bool)
+	for len(nodes) < n {
+		x, y := rng.Intn(grid.Width), rng.Intn(grid.Height)
+		if grid.IsObstacle(x, y) || seen[coordinate{x, y}] {
+			continue
+		}
+		seen[coordinate{x, y}] = true
+		node, _ := grid.GetNode(x, y)
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// assertRouteVisits checks the route path is contiguous, starts at start,
+// visits the waypoints in the reported order and ends correctly
+func assertRouteVisits(t *testing.T, grid *Grid, route *Route, start *Node, waypoints []*Node, end *Node) {
+	t.Helper()
+
+	assertContiguousPath(t, grid, route.Path)
+	if !route.Path[0].Equals(start) {
+		t.Error("Route should begin at start")
+	}
+
+	if len(route.Order) != len(waypoints) {
+		t.Fatalf("Order has %d entries, expected %d", len(route.Order), len(waypoints))
+	}
+	seen := make(map[int]bool)
+	pos := 0
+	for _, w := range route.Order {
+		if seen[w] {
+			t.Errorf("Waypoint %d visited twice in order", w)
+		}
+		seen[w] = true
+		for pos < len(route.Path) && !route.Path[pos].Equals(waypoints[w]) {
+			pos++
+		}
+		if pos == len(route.Path) {
+			t.Fatalf("Path does not visit waypoint %d in order", w)
+		}
+	}
+
+	if end != nil && !route.Path[len(route.Path)-1].Equals(end) {
+		t.Error("Route should finish at end")
+	}
+
+	if math.Abs(PathCost(grid, route.Path)-route.Cost) > 1e-9 {
+		t.Errorf("Route cost %.3f does not match path cost %.3f", route.Cost, PathCost(grid, route.Path))
+	}
+}
+
+// TestWaypointRouter_Route_Exact tests that the exact order matches brute
+// force over all permutations, with and without an end
+func TestWaypointRouter_Route_Exact(t *testing.T) {
+	rng := rand.New(rand.NewSource(20))
+
+	for trial := 0; trial < 10; trial++ {
+		grid := newWaypointTestGrid(rng)
+		nodes := randomFreeNodes(rng, grid, 8)
+		start, waypoints := nodes[0], nodes[1:7]
+		end := nodes[7]
+		if trial%2 == 0 {
+			end = nil
+		}
+
+		astar := NewAStar()
+		astar.SetHeuristic(Diagonal)
+		router := NewWaypointRouter()
+		router.SetPathfinder(astar)
+		router.SetGrid(grid)
+
+		route, err := router.Route(start, waypoints, end)
+		if err != nil {
+			// Random obstacles may cut off a point
+			continue
+		}
+
+		if !route.Exact {
+			t.Error("Six waypoints should be solved exactly")
+		}
+		assertRouteVisits(t, grid, route, start, waypoints, end)
+
+		points := append([]*Node{start}, waypoints...)
+		if end != nil {
+			points = append(points, end)
+		}
+		_, costs := router.legs(points, len(waypoints), end != nil)
+		if want := bruteForceRouteCost(costs, len(waypoints), end != nil); math.Abs(route.Cost-want) > 1e-9 {
+			t.Errorf("trial %d: cost %.3f, brute force %.3f", trial, route.Cost, want)
+		}
+	}
+}
+
+// TestWaypointRouter_Route_Heuristic tests the 2-opt solver for larger sets
+func TestWaypointRouter_Route_Heuristic(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	grid, _ := NewGrid(30, 30, EightWay)
+	nodes := randomFreeNodes(rng, grid, 21)
+	start, waypoints, end := nodes[0], nodes[1:20], nodes[20]
+
+	router := NewWaypointRouter()
+	router.SetGrid(grid)
+	router.SetPathfinder(NewJPS())
+
+	route, err := router.Route(start, waypoints, end)
+	if err != nil {
+		t.Fatalf("Route() returned error: %v", err)
+	}
+
+	if route.Exact {
+		t.Error("19 waypoints exceed the default exact limit")
+	}
+	assertRouteVisits(t, grid, route, start, waypoints, end)
+
+	// A 2-opt optimum has no crossing legs on an open grid, so it beats
+	// the naive input order by a wide margin
+	points := append(append([]*Node{start}, waypoints...), end)
+	_, costs := router.legs(points, len(waypoints), true)
+	naive := make([]int, len(waypoints))
+	for i := range naive {
+		naive[i] = i
+	}
+	if route.Cost >= routeCost(costs, naive, true) {
+		t.Errorf("Heuristic cost %.1f should beat the input order %.1f", route.Cost, routeCost(costs, naive, true))
+	}
+}
+
+// TestWaypointRouter_Route_MatchesExact tests that the heuristic is close
+// to the exact solution on small instances
+func TestWaypointRouter_Route_MatchesExact(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	grid, _ := NewGrid(20, 20, FourWay)
+	nodes := randomFreeNodes(rng, grid, 9)
+
+	router := NewWaypointRouter()
+	router.SetGrid(grid)
+	exact, err := router.Route(nodes[0], nodes[1:], nil)
+	if err != nil {
+		t.Fatalf("Route() returned error: %v", err)
+	}
+
+	router.SetExactLimit(0)
+	heuristic, err := router.Route(nodes[0], nodes[1:], nil)
+	if err != nil {
+		t.Fatalf("Route() returned error: %v", err)
+	}
+
+	if heuristic.Cost < exact.Cost-1e-9 {
+		t.Errorf("Heuristic cost %.1f beats exact cost %.1f", heuristic.Cost, exact.Cost)
+	}
+	if heuristic.Cost > 1.25*exact.Cost {
+		t.Errorf("Heuristic cost %.1f is far from exact cost %.1f", heuristic.Cost, exact.Cost)
+	}
+}
+
+// TestWaypointRouter_Route_EdgeCases tests empty and degenerate waypoint sets
+func TestWaypointRouter_Route_EdgeCases(t *testing.T) {
+	grid, _ := NewGrid(5, 5, FourWay)
+	start, _ := grid.GetNode(0, 0)
+	end, _ := grid.GetNode(4, 4)
+
+	router := NewWaypointRouter()
+	router.SetGrid(grid)
+
+	route, err := router.Route(start, nil, nil)
+	if err != nil || len(route.Path) != 1 || route.Cost != 0 {
+		t.Errorf("Expected single-node route without waypoints, got %v (err=%v)", route, err)
+	}
+
+	route, err = router.Route(start, nil, end)
+	if err != nil || route.Cost != 8 {
+		t.Errorf("Expected direct route of cost 8, got %v (err=%v)", route, err)
+	}
+
+	// Waypoints on the start and the end add nothing
+	route, err = router.Route(start, []*Node{end, start}, end)
+	if err != nil || route.Cost != 8 {
+		t.Errorf("Expected route of cost 8, got %v (err=%v)", route, err)
+	}
+}
+
+// TestWaypointRouter_Route_InputValidation tests error handling for invalid inputs
+func TestWaypointRouter_Route_InputValidation(t *testing.T) {
+	router := NewWaypointRouter()
+	grid, _ := NewGrid(5, 5, FourWay)
+	start, _ := grid.GetNode(0, 0)
+	waypoint, _ := grid.GetNode(4, 0)
+
+	if _, err := router.Route(start, []*Node{waypoint}, nil); err == nil {
+		t.Error("Route() should return error when grid is not set")
+	}
+
+	router.SetGrid(grid)
+	if _, err := router.Route(start, []*Node{nil}, nil); err == nil {
+		t.Error("Route() should return error for a nil waypoint")
+	}
+
+	if err := router.SetExactLimit(17); err == nil {
+		t.Error("SetExactLimit(17) should return error")
+	}
+
+	for y := 0; y < 5; y++ {
+		grid.SetObstacle(2, y)
+	}
+	if _, err := router.Route(start, []*Node{waypoint}, nil); err == nil {
+		t.Error("Route() should return error for an unreachable waypoint")
+	}
+
+	router.SetPathfinder(nil)
+	if _, err := router.Route(start, nil, nil); err == nil {
+		t.Error("Route() should return error when pathfinder is nil")
+	}
+}
@@ -0,0 +1,323 @@
+package mapf
+
+import (
+	"container/heap"
+	"fmt"
+
+	"github.com/edgejay/go-pathfinding/algo"
+)
+
+// Solution is a set of collision-free plans, one per agent.
+type Solution struct {
+	// Paths holds each agent's timed path, indexed like the input agents
+	Paths []TimedPath
+
+	// SumOfCosts is the sum over agents of the time of their last arrival at the goal
+	SumOfCosts int
+
+	// Makespan is the time at which the last agent arrives
+	Makespan int
+
+	// NodesExpanded counts search nodes expanded by the solver: constraint
+	// tree nodes for CBS, space-time states for WHCA*
+	NodesExpanded int
+}
+
+// constraint forbids an agent from occupying a cell (vertex constraint) or
+// traversing an edge (edge constraint) at a time step.
+type constraint struct {
+	agent int
+
+	// x, y is the forbidden cell, or the edge's destination
+	x, y int
+
+	// fromX, fromY is the edge's source (edge constraints only)
+	fromX, fromY int
+
+	// t is the time of arrival at x, y
+	t int
+
+	edge bool
+}
+
+// constraintSet holds one agent's constraints for fast lookup.
+type constraintSet struct {
+	vertex map[spaceTimeState]bool
+	edge   map[[5]int]bool
+
+	// lastGoal is the latest time the agent's goal is forbidden (-1 if never)
+	lastGoal int
+
+	// lastTime is the latest time of any constraint (-1 if none)
+	lastTime int
+}
+
+// ctNode is a node of the CBS constraint tree.
+type ctNode struct {
+	constraints []constraint
+	paths       []TimedPath
+	cost        int
+	conflicts   int
+}
+
+// ctQueue is a min-heap of constraint tree nodes ordered by cost, then by
+// number of conflicts.
+type ctQueue []*ctNode
+
+// Len returns the number of nodes.
+func (q ctQueue) Len() int { return len(q) }
+
+// Less orders by sum of costs, breaking ties toward fewer conflicts.
+func (q ctQueue) Less(i, j int) bool {
+	if q[i].cost != q[j].cost {
+		return q[i].cost < q[j].cost
+	}
+	return q[i].conflicts < q[j].conflicts
+}
+
+// Swap exchanges two nodes.
+func (q ctQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+// Push adds a node (heap.Interface).
+func (q *ctQueue) Push(x interface{}) { *q = append(*q, x.(*ctNode)) }
+
+// Pop removes the last node (heap.Interface).
+func (q *ctQueue) Pop() interface{} {
+	old := *q
+	node := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return node
+}
+
+// CBS implements Conflict-Based Search (Sharon, Stern, Felner & Sturtevant, 2015)
+// for optimal multi-agent pathfinding.
+//
+// The high level searches a binary constraint tree. Each tree node plans
+// every agent independently with a space-time A* that respects the node's
+// constraints, then looks for the earliest conflict. A vertex conflict
+// (two agents in one cell at time t) or edge conflict (two agents swapping
+// cells at time t) is resolved by branching: each child forbids one of the
+// two agents from that cell or move at time t. Tree nodes are expanded in
+// order of sum of costs, so the first conflict-free node is optimal.
+//
+// Every move and every wait costs one time step; Node.Cost is ignored. An
+// agent's cost is the time it last arrives at its goal. Diagonal moves on
+// EightWay grids are allowed, but agents crossing diagonals in an X are not
+// treated as colliding. CBS is exponential in the number of conflicts, so
+// the number of tree nodes is capped (see SetMaxNodes).
+type CBS struct {
+	// grid is the shared search space
+	grid algo.GridInterface
+
+	// maxNodes caps the number of constraint tree nodes expanded
+	maxNodes int
+
+	// distances caches per-goal step distances for the low-level heuristic
+	distances map[[2]int]map[[2]int]int
+}
+
+// NewCBS creates a new CBS solver.
+// By default, it expands at most 10000 constraint tree nodes per Solve.
+//
+// Returns:
+//
+//	*CBS: new CBS solver
+func NewCBS() *CBS {
+	return &CBS{maxNodes: 10000}
+}
+
+// SetGrid configures the grid shared by all agents.
+//
+// Params:
+//
+//	grid: grid to plan on
+func (c *CBS) SetGrid(grid algo.GridInterface) {
+	c.grid = grid
+}
+
+// SetMaxNodes caps how many constraint tree nodes Solve may expand before
+// giving up. Unsolvable instances are only detected through this limit.
+//
+// Params:
+//
+//	n: maximum number of expanded tree nodes (must be > 0)
+//
+// Returns:
+//
+//	error: if n is not positive
+func (c *CBS) SetMaxNodes(n int) error {
+	if n <= 0 {
+		return fmt.Errorf("maximum nodes must be > 0, got %d", n)
+	}
+	c.maxNodes = n
+	return nil
+}
+
+// Solve finds collision-free paths for all agents with minimal sum of costs.
+//
+// Params:
+//
+//	agents: agents with distinct starts and distinct goals
+//
+// Returns:
+//
+//	*Solution: per-agent timed paths and their costs
+//	error: if input is invalid, an agent cannot reach its goal, or the node limit is reached
+func (c *CBS) Solve(agents []Agent) (*Solution, error) {
+	if err := validateAgents(c.grid, agents); err != nil {
+		return nil, err
+	}
+
+	c.distances = make(map[[2]int]map[[2]int]int)
+	for i, agent := range agents {
+		if _, ok := c.distanceTable(agent.Goal)[[2]int{agent.Start.X, agent.Start.Y}]; !ok {
+			return nil, fmt.Errorf("agent %d: no path found from (%d, %d) to (%d, %d)",
+				i, agent.Start.X, agent.Start.Y, agent.Goal.X, agent.Goal.Y)
+		}
+	}
+
+	root := &ctNode{paths: make([]TimedPath, len(agents))}
+	for i := range agents {
+		path, ok := c.planAgent(agents, i, nil)
+		if !ok {
+			return nil, fmt.Errorf("agent %d: no path found", i)
+		}
+		root.paths[i] = path
+	}
+	c.evaluate(root)
+
+	open := &ctQueue{root}
+	expanded := 0
+
+	for open.Len() > 0 {
+		node := heap.Pop(open).(*ctNode)
+
+		conflict, found := FirstConflict(node.paths)
+		if !found {
+			return newSolution(node.paths, expanded), nil
+		}
+
+		if expanded >= c.maxNodes {
+			return nil, fmt.Errorf("no conflict-free solution found within %d constraint tree nodes", c.maxNodes)
+		}
+		expanded++
+
+		for _, con := range splitConflict(node.paths, conflict) {
+			child := &ctNode{
+				constraints: append(append([]constraint(nil), node.constraints...), con),
+				paths:       append([]TimedPath(nil), node.paths...),
+			}
+
+			path, ok := c.planAgent(agents, con.agent, child.constraints)
+			if !ok {
+				continue
+			}
+			child.paths[con.agent] = path
+			c.evaluate(child)
+			heap.Push(open, child)
+		}
+	}
+
+	return nil, fmt.Errorf("no conflict-free solution exists")
+}
+
+// planAgent runs the low-level space-time A* for one agent under the
+// constraints that apply to it.
+func (c *CBS) planAgent(agents []Agent, agent int, constraints []constraint) (TimedPath, bool) {
+	set := constraintSet{
+		vertex:   make(map[spaceTimeState]bool),
+		edge:     make(map[[5]int]bool),
+		lastGoal: -1,
+		lastTime: -1,
+	}
+	goal := agents[agent].Goal
+	for _, con := range constraints {
+		if con.agent != agent {
+			continue
+		}
+		if con.edge {
+			set.edge[[5]int{con.fromX, con.fromY, con.x, con.y, con.t}] = true
+		} else {
+			set.vertex[spaceTimeState{con.x, con.y, con.t}] = true
+			if con.x == goal.X && con.y == goal.Y {
+				set.lastGoal = max(set.lastGoal, con.t)
+			}
+		}
+		set.lastTime = max(set.lastTime, con.t)
+	}
+
+	distances := c.distanceTable(goal)
+	problem := &spaceTimeProblem{
+		grid:  c.grid,
+		start: agents[agent].Start,
+		goal:  goal,
+		heuristic: func(node *algo.Node) float64 {
+			return float64(distances[[2]int{node.X, node.Y}])
+		},
+		cost: func(from, to *algo.Node) float64 {
+			return 1
+		},
+		blocked: func(from, to *algo.Node, t int) bool {
+			return set.vertex[spaceTimeState{to.X, to.Y, t}] ||
+				set.edge[[5]int{from.X, from.Y, to.X, to.Y, t}]
+		},
+		canFinish: func(t int) bool {
+			return t > set.lastGoal
+		},
+		// Past the last constraint, waiting never helps, so the shortest
+		// path needs at most one extra step per reachable cell
+		maxTime: set.lastTime + len(distances) + 1,
+	}
+
+	path, _, _, ok := problem.solve()
+	return path, ok
+}
+
+// distanceTable returns the cached step distances to goal.
+func (c *CBS) distanceTable(goal *algo.Node) map[[2]int]int {
+	key := [2]int{goal.X, goal.Y}
+	if table, ok := c.distances[key]; ok {
+		return table
+	}
+	table := stepDistances(c.grid, goal)
+	c.distances[key] = table
+	return table
+}
+
+// evaluate computes a tree node's sum of costs and conflict count.
+func (c *CBS) evaluate(node *ctNode) {
+	node.cost = 0
+	for _, path := range node.paths {
+		node.cost += path[len(path)-1].Time
+	}
+	node.conflicts = len(findConflicts(node.paths, false))
+}
+
+// splitConflict returns the two constraints that resolve a conflict, one
+// for each agent involved.
+func splitConflict(paths []TimedPath, conflict Conflict) []constraint {
+	a, b, t := conflict.AgentA, conflict.AgentB, conflict.Time
+	if !conflict.Edge {
+		return []constraint{
+			{agent: a, x: conflict.X, y: conflict.Y, t: t},
+			{agent: b, x: conflict.X, y: conflict.Y, t: t},
+		}
+	}
+
+	fromA, toA := paths[a].At(t-1), paths[a].At(t)
+	return []constraint{
+		{agent: a, x: toA.X, y: toA.Y, fromX: fromA.X, fromY: fromA.Y, t: t, edge: true},
+		{agent: b, x: fromA.X, y: fromA.Y, fromX: toA.X, fromY: toA.Y, t: t, edge: true},
+	}
+}
+
+// newSolution wraps conflict-free paths in a Solution.
+func newSolution(paths []TimedPath, expanded int) *Solution {
+	solution := &Solution{Paths: paths, NodesExpanded: expanded}
+	for _, path := range paths {
+		arrival := path[len(path)-1].Time
+		solution.SumOfCosts += arrival
+		solution.Makespan = max(solution.Makespan, arrival)
+	}
+	return solution
+}
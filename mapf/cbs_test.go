@@ -0,0 +1,350 @@
+package mapf
+
+import (
+	"container/heap"
+	"math/rand"
+	"testing"
+
+	"github.com/edgejay/go-pathfinding/algo"
+)
+
+// jointState is a configuration of two agents for the brute-force oracle
+type jointState struct {
+	a, b         [2]int
+	doneA, doneB bool
+}
+
+// jointItem is a priority queue entry of the brute-force oracle
+type jointItem struct {
+	state jointState
+	cost  int
+}
+
+// jointQueue is a min-heap of jointItems by cost
+type jointQueue []jointItem
+
+func (q jointQueue) Len() int            { return len(q) }
+func (q jointQueue) Less(i, j int) bool  { return q[i].cost < q[j].cost }
+func (q jointQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *jointQueue) Push(x interface{}) { *q = append(*q, x.(jointItem)) }
+func (q *jointQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// optimalSumOfCosts computes the optimal sum of costs for two agents by
+// Dijkstra over joint configurations. An agent standing on its goal may
+// "finish" for free and then never moves again; every step costs one per
+// unfinished agent. Returns -1 if the instance is unsolvable.
+func optimalSumOfCosts(grid *algo.Grid, agents []Agent) int {
+	moves := func(pos [2]int, done bool) [][2]int {
+		if done {
+			return [][2]int{pos}
+		}
+		node, _ := grid.GetNode(pos[0], pos[1])
+		result := [][2]int{pos}
+		for _, n := range grid.GetNeighbors(node) {
+			result = append(result, [2]int{n.X, n.Y})
+		}
+		return result
+	}
+
+	goalA := [2]int{agents[0].Goal.X, agents[0].Goal.Y}
+	goalB := [2]int{agents[1].Goal.X, agents[1].Goal.Y}
+	start := jointState{a: [2]int{agents[0].Start.X, agents[0].Start.Y}, b: [2]int{agents[1].Start.X, agents[1].Start.Y}}
+
+	best := map[jointState]int{start: 0}
+	open := &jointQueue{{state: start}}
+	for open.Len() > 0 {
+		item := heap.Pop(open).(jointItem)
+		s := item.state
+		if item.cost > best[s] {
+			continue
+		}
+		if s.doneA && s.doneB {
+			return item.cost
+		}
+
+		relax := func(next jointState, cost int) {
+			if old, ok := best[next]; !ok || cost < old {
+				best[next] = cost
+				heap.Push(open, jointItem{state: next, cost: cost})
+			}
+		}
+
+		// Finishing is free for an agent on its goal
+		if !s.doneA && s.a == goalA {
+			relax(jointState{s.a, s.b, true, s.doneB}, item.cost)
+		}
+		if !s.doneB && s.b == goalB {
+			relax(jointState{s.a, s.b, s.doneA, true}, item.cost)
+		}
+
+		stepCost := 0
+		if !s.doneA {
+			stepCost++
+		}
+		if !s.doneB {
+			stepCost++
+		}
+		for _, na := range moves(s.a, s.doneA) {
+			for _, nb := range moves(s.b, s.doneB) {
+				if na == nb || (na == s.b && nb == s.a) {
+					continue
+				}
+				relax(jointState{na, nb, s.doneA, s.doneB}, item.cost+stepCost)
+			}
+		}
+	}
+	return -1
+}
+
+// assertValidSolution checks that paths start and end at the agents'
+// endpoints, only use legal moves and are conflict-free
+func assertValidSolution(t *testing.T, grid *algo.Grid, agents []Agent, solution *Solution) {
+	t.Helper()
+
+	if conflict, found := FirstConflict(solution.Paths); found {
+		t.Fatalf("Solution has conflict %+v", conflict)
+	}
+
+	sum := 0
+	for i, path := range solution.Paths {
+		if !path[0].Node.Equals(agents[i].Start) || path[0].Time != 0 {
+			t.Errorf("agent %d: path does not start at its start at time 0", i)
+		}
+		if !path[len(path)-1].Node.Equals(agents[i].Goal) {
+			t.Errorf("agent %d: path does not end at its goal", i)
+		}
+		for j := 1; j < len(path); j++ {
+			prev, cur := path[j-1].Node, path[j].Node
+			if path[j].Time != path[j-1].Time+1 {
+				t.Errorf("agent %d: time jumps from %d to %d", i, path[j-1].Time, path[j].Time)
+			}
+			if prev.Equals(cur) {
+				continue
+			}
+			adjacent := false
+			for _, n := range grid.GetNeighbors(prev) {
+				if n.Equals(cur) {
+					adjacent = true
+				}
+			}
+			if !adjacent {
+				t.Errorf("agent %d: illegal move (%d, %d) -> (%d, %d)", i, prev.X, prev.Y, cur.X, cur.Y)
+			}
+		}
+		sum += path[len(path)-1].Time
+	}
+
+	if sum != solution.SumOfCosts {
+		t.Errorf("SumOfCosts = %d, paths sum to %d", solution.SumOfCosts, sum)
+	}
+}
+
+// agentAt builds an agent from start and goal coordinates
+func agentAt(grid *algo.Grid, sx, sy, gx, gy int) Agent {
+	start, _ := grid.GetNode(sx, sy)
+	goal, _ := grid.GetNode(gx, gy)
+	return Agent{Start: start, Goal: goal}
+}
+
+// TestCBS_Solve_Crossing tests two agents whose shortest paths cross
+func TestCBS_Solve_Crossing(t *testing.T) {
+	grid, _ := algo.NewGrid(3, 3, algo.FourWay)
+	agents := []Agent{
+		agentAt(grid, 0, 1, 2, 1),
+		agentAt(grid, 1, 0, 1, 2),
+	}
+
+	cbs := NewCBS()
+	cbs.SetGrid(grid)
+	solution, err := cbs.Solve(agents)
+	if err != nil {
+		t.Fatalf("Solve() returned error: %v", err)
+	}
+
+	assertValidSolution(t, grid, agents, solution)
+
+	// One agent has to wait (or detour) for a single step
+	if solution.SumOfCosts != 5 {
+		t.Errorf("Expected sum of costs 5, got %d", solution.SumOfCosts)
+	}
+	if solution.Makespan != 3 {
+		t.Errorf("Expected makespan 3, got %d", solution.Makespan)
+	}
+}
+
+// TestCBS_Solve_CorridorSwap tests two agents swapping through a corridor
+// with a single side pocket
+func TestCBS_Solve_CorridorSwap(t *testing.T) {
+	grid, _ := algo.NewGrid(5, 2, algo.FourWay)
+	for x := 0; x < 5; x++ {
+		if x != 2 {
+			grid.SetObstacle(x, 1)
+		}
+	}
+	agents := []Agent{
+		agentAt(grid, 0, 0, 4, 0),
+		agentAt(grid, 4, 0, 0, 0),
+	}
+
+	cbs := NewCBS()
+	cbs.SetGrid(grid)
+	solution, err := cbs.Solve(agents)
+	if err != nil {
+		t.Fatalf("Solve() returned error: %v", err)
+	}
+
+	assertValidSolution(t, grid, agents, solution)
+
+	if want := optimalSumOfCosts(grid, agents); solution.SumOfCosts != want {
+		t.Errorf("Expected optimal sum of costs %d, got %d", want, solution.SumOfCosts)
+	}
+}
+
+// TestCBS_Solve_MatchesJointSearch tests optimality against a brute-force
+// joint-space search on random small maps
+func TestCBS_Solve_MatchesJointSearch(t *testing.T) {
+	rng := rand.New(rand.NewSource(21))
+
+	for trial := 0; trial < 40; trial++ {
+		movement := algo.FourWay
+		if trial%2 == 1 {
+			movement = algo.EightWay
+		}
+		grid, _ := algo.NewGrid(4, 4, movement)
+		for i := 0; i < 4; i++ {
+			grid.SetObstacle(rng.Intn(4), rng.Intn(4))
+		}
+
+		var free [][2]int
+		for y := 0; y < 4; y++ {
+			for x := 0; x < 4; x++ {
+				if !grid.IsObstacle(x, y) {
+					free = append(free, [2]int{x, y})
+				}
+			}
+		}
+		rng.Shuffle(len(free), func(i, j int) { free[i], free[j] = free[j], free[i] })
+		agents := []Agent{
+			agentAt(grid, free[0][0], free[0][1], free[1][0], free[1][1]),
+			agentAt(grid, free[2][0], free[2][1], free[3][0], free[3][1]),
+		}
+
+		want := optimalSumOfCosts(grid, agents)
+
+		cbs := NewCBS()
+		cbs.SetGrid(grid)
+		cbs.SetMaxNodes(2000)
+		solution, err := cbs.Solve(agents)
+
+		if want < 0 {
+			if err == nil {
+				t.Errorf("trial %d: expected error for unsolvable instance", trial)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("trial %d: Solve() returned error: %v\n%s", trial, err, grid)
+		}
+
+		assertValidSolution(t, grid, agents, solution)
+		if solution.SumOfCosts != want {
+			t.Errorf("trial %d: sum of costs %d, optimal %d\n%s", trial, solution.SumOfCosts, want, grid)
+		}
+	}
+}
+
+// TestCBS_Solve_ManyAgents tests a larger instance for validity
+func TestCBS_Solve_ManyAgents(t *testing.T) {
+	grid, _ := algo.NewGrid(8, 8, algo.FourWay)
+	grid.SetObstacle(3, 3)
+	grid.SetObstacle(4, 4)
+	agents := []Agent{
+		agentAt(grid, 0, 0, 7, 7),
+		agentAt(grid, 7, 7, 0, 0),
+		agentAt(grid, 0, 7, 7, 0),
+		agentAt(grid, 7, 0, 0, 7),
+		agentAt(grid, 3, 0, 3, 7),
+	}
+
+	cbs := NewCBS()
+	cbs.SetGrid(grid)
+	solution, err := cbs.Solve(agents)
+	if err != nil {
+		t.Fatalf("Solve() returned error: %v", err)
+	}
+
+	assertValidSolution(t, grid, agents, solution)
+
+	// Sum of costs is at least the sum of individual shortest paths
+	if solution.SumOfCosts < 14*4+7 {
+		t.Errorf("Sum of costs %d is below the lower bound %d", solution.SumOfCosts, 14*4+7)
+	}
+}
+
+// TestCBS_Solve_Unsolvable tests that an impossible swap hits the node limit
+func TestCBS_Solve_Unsolvable(t *testing.T) {
+	grid, _ := algo.NewGrid(3, 1, algo.FourWay)
+	agents := []Agent{
+		agentAt(grid, 0, 0, 2, 0),
+		agentAt(grid, 2, 0, 0, 0),
+	}
+
+	cbs := NewCBS()
+	cbs.SetGrid(grid)
+	if err := cbs.SetMaxNodes(50); err != nil {
+		t.Fatalf("SetMaxNodes() returned error: %v", err)
+	}
+
+	if _, err := cbs.Solve(agents); err == nil {
+		t.Error("Solve() should fail for an impossible corridor swap")
+	}
+}
+
+// TestCBS_Solve_InputValidation tests error handling for invalid inputs
+func TestCBS_Solve_InputValidation(t *testing.T) {
+	cbs := NewCBS()
+	grid, _ := algo.NewGrid(4, 4, algo.FourWay)
+
+	if _, err := cbs.Solve([]Agent{agentAt(grid, 0, 0, 1, 1)}); err == nil {
+		t.Error("Solve() should return error when grid is not set")
+	}
+
+	cbs.SetGrid(grid)
+	if err := cbs.SetMaxNodes(0); err == nil {
+		t.Error("SetMaxNodes(0) should return error")
+	}
+
+	if _, err := cbs.Solve([]Agent{{Start: nil}}); err == nil {
+		t.Error("Solve() should return error for nil endpoints")
+	}
+
+	if _, err := cbs.Solve([]Agent{agentAt(grid, 0, 0, 3, 3), agentAt(grid, 0, 0, 2, 2)}); err == nil {
+		t.Error("Solve() should return error for shared starts")
+	}
+
+	if _, err := cbs.Solve([]Agent{agentAt(grid, 0, 0, 3, 3), agentAt(grid, 1, 0, 3, 3)}); err == nil {
+		t.Error("Solve() should return error for shared goals")
+	}
+
+	grid.SetObstacle(1, 1)
+	if _, err := cbs.Solve([]Agent{agentAt(grid, 0, 0, 1, 1)}); err == nil {
+		t.Error("Solve() should return error for obstacle goal")
+	}
+
+	for y := 0; y < 4; y++ {
+		grid.SetObstacle(2, y)
+	}
+	if _, err := cbs.Solve([]Agent{agentAt(grid, 0, 0, 3, 3)}); err == nil {
+		t.Error("Solve() should return error for unreachable goal")
+	}
+
+	solution, err := cbs.Solve(nil)
+	if err != nil || len(solution.Paths) != 0 {
+		t.Errorf("Solve(nil) should return an empty solution, got %v (err=%v)", solution, err)
+	}
+}
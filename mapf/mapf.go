@@ -0,0 +1,358 @@
+// Package mapf provides multi-agent pathfinding on top of the algo package.
+//
+// Agents move on a shared algo.GridInterface in discrete time steps. At
+// every step an agent either moves to a neighboring cell or waits in
+// place. Plans are returned as timed paths and are free of vertex conflicts
+// (two agents in the same cell at the same time) and edge conflicts (two
+// agents swapping cells during the same step).
+//
+// The same space-time search also plans a single agent around obstacles
+// scheduled on a known timeline (see SpaceTimeAStar).
+package mapf
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+
+	"github.com/edgejay/go-pathfinding/algo"
+)
+
+// Agent is a single agent's pathfinding request.
+type Agent struct {
+	// Start is the agent's cell at time 0
+	Start *algo.Node
+
+	// Goal is the cell the agent must reach and stay at
+	Goal *algo.Node
+}
+
+// TimedNode is a cell occupied at a given time step.
+type TimedNode struct {
+	Node *algo.Node
+	Time int
+}
+
+// TimedPath is an agent's plan, with one entry per time step starting at
+// time 0. Consecutive entries on the same cell are wait actions. After its
+// last entry the agent stays on the final cell.
+type TimedPath []TimedNode
+
+// At returns the cell the agent occupies at time t. Before the path starts
+// this is the first cell; after it ends, the last.
+//
+// Params:
+//
+//	t: time step
+//
+// Returns:
+//
+//	*algo.Node: occupied cell, or nil for an empty path
+func (p TimedPath) At(t int) *algo.Node {
+	if len(p) == 0 {
+		return nil
+	}
+	if t <= p[0].Time {
+		return p[0].Node
+	}
+	if t >= p[len(p)-1].Time {
+		return p[len(p)-1].Node
+	}
+	return p[t-p[0].Time].Node
+}
+
+// Nodes returns the cells of the path without timing information.
+//
+// Returns:
+//
+//	[]*algo.Node: cells in path order, including waits
+func (p TimedPath) Nodes() []*algo.Node {
+	nodes := make([]*algo.Node, len(p))
+	for i, step := range p {
+		nodes[i] = step.Node
+	}
+	return nodes
+}
+
+// Conflict describes two agents colliding.
+type Conflict struct {
+	// AgentA and AgentB are the indices of the colliding agents (AgentA < AgentB)
+	AgentA, AgentB int
+
+	// Time is the step at which the collision happens
+	Time int
+
+	// X, Y is the contested cell. For edge conflicts it is AgentA's
+	// destination, which AgentB leaves during the same step.
+	X, Y int
+
+	// Edge is true for swap conflicts and false for vertex conflicts
+	Edge bool
+}
+
+// FirstConflict returns the earliest conflict between any two paths.
+// Agents are assumed to stay on their last cell once their path ends.
+//
+// Params:
+//
+//	paths: timed paths, one per agent
+//
+// Returns:
+//
+//	Conflict: earliest conflict found
+//	bool: true if any conflict exists
+func FirstConflict(paths []TimedPath) (Conflict, bool) {
+	conflicts := findConflicts(paths, true)
+	if len(conflicts) == 0 {
+		return Conflict{}, false
+	}
+	return conflicts[0], true
+}
+
+// findConflicts lists conflicts in time order, stopping at the first one
+// if firstOnly is set.
+func findConflicts(paths []TimedPath, firstOnly bool) []Conflict {
+	horizon := 0
+	for _, p := range paths {
+		if len(p) > 0 {
+			horizon = max(horizon, p[len(p)-1].Time)
+		}
+	}
+
+	var conflicts []Conflict
+	for t := 0; t <= horizon; t++ {
+		for a := 0; a < len(paths); a++ {
+			for b := a + 1; b < len(paths); b++ {
+				if len(paths[a]) == 0 || len(paths[b]) == 0 {
+					continue
+				}
+
+				here := paths[a].At(t)
+				if here.Equals(paths[b].At(t)) {
+					conflicts = append(conflicts, Conflict{AgentA: a, AgentB: b, Time: t, X: here.X, Y: here.Y})
+				} else if t > 0 && here.Equals(paths[b].At(t-1)) && paths[a].At(t-1).Equals(paths[b].At(t)) {
+					conflicts = append(conflicts, Conflict{AgentA: a, AgentB: b, Time: t, X: here.X, Y: here.Y, Edge: true})
+				}
+
+				if firstOnly && len(conflicts) > 0 {
+					return conflicts
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+// validateAgents checks the grid and agent endpoints shared by all solvers.
+func validateAgents(grid algo.GridInterface, agents []Agent) error {
+	if grid == nil {
+		return fmt.Errorf("grid must be set before calling Solve")
+	}
+
+	starts := make(map[[2]int]int)
+	goals := make(map[[2]int]int)
+	for i, agent := range agents {
+		if agent.Start == nil || agent.Goal == nil {
+			return fmt.Errorf("agent %d: start and goal nodes cannot be nil", i)
+		}
+		if agent.Start.IsObstacle {
+			return fmt.Errorf("agent %d: start node at (%d, %d) is an obstacle", i, agent.Start.X, agent.Start.Y)
+		}
+		if agent.Goal.IsObstacle {
+			return fmt.Errorf("agent %d: goal node at (%d, %d) is an obstacle", i, agent.Goal.X, agent.Goal.Y)
+		}
+
+		start := [2]int{agent.Start.X, agent.Start.Y}
+		if j, ok := starts[start]; ok {
+			return fmt.Errorf("agents %d and %d share start (%d, %d)", j, i, start[0], start[1])
+		}
+		starts[start] = i
+
+		goal := [2]int{agent.Goal.X, agent.Goal.Y}
+		if j, ok := goals[goal]; ok {
+			return fmt.Errorf("agents %d and %d share goal (%d, %d)", j, i, goal[0], goal[1])
+		}
+		goals[goal] = i
+	}
+	return nil
+}
+
+// stepDistances computes the minimum number of moves from every reachable
+// cell to goal with a breadth-first search. It is an exact, consistent
+// heuristic for unit-cost space-time searches. Neighbor relations are
+// assumed to be symmetric, as they are for algo.Grid.
+func stepDistances(grid algo.GridInterface, goal *algo.Node) map[[2]int]int {
+	dist := map[[2]int]int{{goal.X, goal.Y}: 0}
+	queue := []*algo.Node{goal}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		d := dist[[2]int{node.X, node.Y}]
+		for _, neighbor := range grid.GetNeighbors(node) {
+			key := [2]int{neighbor.X, neighbor.Y}
+			if _, seen := dist[key]; !seen && !neighbor.IsObstacle {
+				dist[key] = d + 1
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+	return dist
+}
+
+// spaceTimeProblem describes a single-agent search over (cell, time) states.
+type spaceTimeProblem struct {
+	// grid is the search space
+	grid algo.GridInterface
+
+	// start is occupied at startTime; the search must end on goal
+	start, goal *algo.Node
+	startTime   int
+
+	// heuristic estimates the remaining cost from a cell to goal
+	heuristic func(node *algo.Node) float64
+
+	// cost returns the cost of moving from one cell to another in one
+	// step; from == to is a wait action
+	cost func(from, to *algo.Node) float64
+
+	// blocked reports whether moving from one cell to another, arriving
+	// at time t, is forbidden
+	blocked func(from, to *algo.Node, t int) bool
+
+	// canFinish reports whether the agent may end its path on goal at time t
+	canFinish func(t int) bool
+
+	// maxTime bounds the search; states after it are not generated
+	maxTime int
+
+	// windowEnd, if > 0, makes states at this time terminal: the search
+	// stops there and trusts the heuristic for the rest of the way
+	windowEnd int
+
+	// settledFrom, if > 0, is a time from which blocked, cost and
+	// canFinish no longer depend on t. States of a cell at or after it are
+	// interchangeable, so only the cheapest of them is expanded
+	settledFrom int
+}
+
+// spaceTimeState is a search state: a cell at a time step.
+type spaceTimeState struct {
+	x, y, t int
+}
+
+// spaceTimeEntry is an open-list entry of the space-time search.
+type spaceTimeEntry struct {
+	node *algo.Node
+	t    int
+	g, f float64
+}
+
+// spaceTimeQueue is a min-heap of entries ordered by f, preferring larger g.
+type spaceTimeQueue []spaceTimeEntry
+
+// Len returns the number of entries.
+func (q spaceTimeQueue) Len() int { return len(q) }
+
+// Less orders by f, breaking ties toward deeper entries.
+func (q spaceTimeQueue) Less(i, j int) bool {
+	if q[i].f != q[j].f {
+		return q[i].f < q[j].f
+	}
+	return q[i].g > q[j].g
+}
+
+// Swap exchanges two entries.
+func (q spaceTimeQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+// Push adds an entry (heap.Interface).
+func (q *spaceTimeQueue) Push(x interface{}) { *q = append(*q, x.(spaceTimeEntry)) }
+
+// Pop removes the last entry (heap.Interface).
+func (q *spaceTimeQueue) Pop() interface{} {
+	old := *q
+	entry := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return entry
+}
+
+// closedKey returns the state under which s is recorded as expanded,
+// merging the time-independent states after settledFrom.
+func (p *spaceTimeProblem) closedKey(s spaceTimeState) spaceTimeState {
+	if p.settledFrom > 0 && s.t > p.settledFrom {
+		s.t = p.settledFrom
+	}
+	return s
+}
+
+// solve runs A* over (cell, time) states. Each step moves to a neighbor
+// or waits; the search ends when goal is reached at a time canFinish
+// accepts, or at the end of the window if one is set.
+//
+// Returns:
+//
+//	TimedPath: path from (start, startTime) to goal or the window end
+//	float64: total cost of the path
+//	int: number of expanded states
+//	bool: true if a path was found
+func (p *spaceTimeProblem) solve() (TimedPath, float64, int, bool) {
+	startState := spaceTimeState{p.start.X, p.start.Y, p.startTime}
+	g := map[spaceTimeState]float64{startState: 0}
+	parent := make(map[spaceTimeState]spaceTimeState)
+	nodes := map[spaceTimeState]*algo.Node{startState: p.start}
+	closed := make(map[spaceTimeState]bool)
+
+	open := &spaceTimeQueue{{node: p.start, t: p.startTime, f: p.heuristic(p.start)}}
+	expanded := 0
+
+	for open.Len() > 0 {
+		entry := heap.Pop(open).(spaceTimeEntry)
+		state := spaceTimeState{entry.node.X, entry.node.Y, entry.t}
+		if closed[p.closedKey(state)] {
+			continue
+		}
+
+		atWindowEnd := p.windowEnd > 0 && entry.t >= p.windowEnd
+		if atWindowEnd || entry.node.Equals(p.goal) && p.canFinish(entry.t) {
+			path := TimedPath{{Node: entry.node, Time: entry.t}}
+			for s := state; s != startState; {
+				s = parent[s]
+				path = append(path, TimedNode{Node: nodes[s], Time: s.t})
+			}
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path, g[state], expanded, true
+		}
+
+		closed[p.closedKey(state)] = true
+		expanded++
+		if entry.t >= p.maxTime {
+			continue
+		}
+
+		t := entry.t + 1
+		successors := append(p.grid.GetNeighbors(entry.node), entry.node)
+		for _, next := range successors {
+			nextState := spaceTimeState{next.X, next.Y, t}
+			if next.IsObstacle || closed[p.closedKey(nextState)] || p.blocked(entry.node, next, t) {
+				continue
+			}
+
+			cost := p.cost(entry.node, next)
+			if math.IsInf(cost, 1) {
+				continue
+			}
+
+			newG := g[state] + cost
+			if old, seen := g[nextState]; seen && newG >= old {
+				continue
+			}
+			g[nextState] = newG
+			parent[nextState] = state
+			nodes[nextState] = next
+			heap.Push(open, spaceTimeEntry{node: next, t: t, g: newG, f: newG + p.heuristic(next)})
+		}
+	}
+
+	return nil, 0, expanded, false
+}
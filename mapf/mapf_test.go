@@ -0,0 +1,106 @@
+package mapf
+
+import (
+	"testing"
+
+	"github.com/edgejay/go-pathfinding/algo"
+)
+
+// timedPath builds a timed path starting at time 0 from cell coordinates
+func timedPath(grid *algo.Grid, cells ...[2]int) TimedPath {
+	path := make(TimedPath, len(cells))
+	for i, c := range cells {
+		node, _ := grid.GetNode(c[0], c[1])
+		path[i] = TimedNode{Node: node, Time: i}
+	}
+	return path
+}
+
+// TestTimedPath_At tests position lookup before, during and after a path
+func TestTimedPath_At(t *testing.T) {
+	grid, _ := algo.NewGrid(3, 3, algo.FourWay)
+	path := timedPath(grid, [2]int{0, 0}, [2]int{1, 0}, [2]int{1, 0}, [2]int{1, 1})
+
+	tests := []struct {
+		time int
+		x, y int
+	}{
+		{-1, 0, 0},
+		{0, 0, 0},
+		{2, 1, 0},
+		{3, 1, 1},
+		{10, 1, 1},
+	}
+
+	for _, tt := range tests {
+		if node := path.At(tt.time); node.X != tt.x || node.Y != tt.y {
+			t.Errorf("At(%d) = (%d, %d), expected (%d, %d)", tt.time, node.X, node.Y, tt.x, tt.y)
+		}
+	}
+
+	if len(path.Nodes()) != 4 {
+		t.Errorf("Expected 4 nodes, got %d", len(path.Nodes()))
+	}
+
+	if (TimedPath{}).At(0) != nil {
+		t.Error("At() on an empty path should return nil")
+	}
+}
+
+// TestFirstConflict tests detection of vertex, edge and post-arrival conflicts
+func TestFirstConflict(t *testing.T) {
+	grid, _ := algo.NewGrid(4, 4, algo.FourWay)
+
+	tests := []struct {
+		name  string
+		paths []TimedPath
+		want  *Conflict
+	}{
+		{
+			name: "no conflict",
+			paths: []TimedPath{
+				timedPath(grid, [2]int{0, 0}, [2]int{1, 0}),
+				timedPath(grid, [2]int{0, 1}, [2]int{1, 1}),
+			},
+		},
+		{
+			name: "vertex conflict",
+			paths: []TimedPath{
+				timedPath(grid, [2]int{0, 1}, [2]int{1, 1}),
+				timedPath(grid, [2]int{1, 0}, [2]int{1, 1}),
+			},
+			want: &Conflict{AgentA: 0, AgentB: 1, Time: 1, X: 1, Y: 1},
+		},
+		{
+			name: "edge conflict",
+			paths: []TimedPath{
+				timedPath(grid, [2]int{0, 0}, [2]int{1, 0}),
+				timedPath(grid, [2]int{1, 0}, [2]int{0, 0}),
+			},
+			want: &Conflict{AgentA: 0, AgentB: 1, Time: 1, X: 1, Y: 0, Edge: true},
+		},
+		{
+			name: "conflict with agent resting at its goal",
+			paths: []TimedPath{
+				timedPath(grid, [2]int{2, 2}),
+				timedPath(grid, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2}),
+			},
+			want: &Conflict{AgentA: 0, AgentB: 1, Time: 2, X: 2, Y: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := FirstConflict(tt.paths)
+			if tt.want == nil {
+				if found {
+					t.Errorf("Expected no conflict, got %+v", got)
+				}
+				return
+			}
+			if !found || got != *tt.want {
+				t.Errorf("Expected %+v, got %+v (found=%v)", *tt.want, got, found)
+			}
+		})
+	}
+}
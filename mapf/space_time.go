@@ -0,0 +1,264 @@
+package mapf
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/edgejay/go-pathfinding/algo"
+)
+
+// interval is a half-open range of time steps [start, end).
+type interval struct {
+	start, end int
+}
+
+// SpaceTimeAStar finds a single agent's cheapest timed path around
+// obstacles that come and go on a known schedule, such as doors that close
+// or patrols that pass through cells.
+//
+// The search runs over (cell, time) states: every step the agent moves to
+// a neighbor, paying Grid.GetCost, or waits in place, paying the wait cost.
+// A cell scheduled as an obstacle cannot be occupied during its interval.
+// Because the whole timeline is known up front, the agent can wait for a
+// door to open or time its way past a patrol; no replanning is involved.
+// The path ends when the agent arrives at the goal, and obstacles scheduled
+// on the goal after arrival are ignored.
+type SpaceTimeAStar struct {
+	// grid holds the static obstacles and movement costs
+	grid *algo.Grid
+
+	// heuristic estimates the remaining movement cost to the goal
+	heuristic algo.HeuristicFunc
+
+	// waitCost is the cost of staying in place for one time step
+	waitCost float64
+
+	// maxTime caps the search horizon (0 derives it from the schedule)
+	maxTime int
+
+	// schedule maps cells to the intervals during which they are blocked
+	schedule map[[2]int][]interval
+
+	// lastEvent is the latest finite time at which the schedule changes
+	lastEvent int
+}
+
+// NewSpaceTimeAStar creates a new space-time A* pathfinder.
+// By default, it uses the Manhattan heuristic, a wait cost of 1 and a
+// search horizon derived from the schedule.
+//
+// Returns:
+//
+//	*SpaceTimeAStar: new space-time A* pathfinder
+func NewSpaceTimeAStar() *SpaceTimeAStar {
+	return &SpaceTimeAStar{
+		heuristic: algo.Manhattan,
+		waitCost:  1,
+		schedule:  make(map[[2]int][]interval),
+	}
+}
+
+// SetGrid configures the grid with the static obstacles and costs.
+//
+// Params:
+//
+//	grid: grid to search within
+func (s *SpaceTimeAStar) SetGrid(grid *algo.Grid) {
+	s.grid = grid
+}
+
+// SetHeuristic configures the heuristic. It must not overestimate the
+// movement cost for paths to be optimal.
+//
+// Params:
+//
+//	heuristic: heuristic function to use
+func (s *SpaceTimeAStar) SetHeuristic(heuristic algo.HeuristicFunc) {
+	s.heuristic = heuristic
+}
+
+// SetWaitCost configures the cost of waiting in place for one time step.
+//
+// Params:
+//
+//	cost: wait cost (must be >= 0)
+//
+// Returns:
+//
+//	error: if cost is negative or not a number
+func (s *SpaceTimeAStar) SetWaitCost(cost float64) error {
+	if math.IsNaN(cost) || math.IsInf(cost, 0) || cost < 0 {
+		return fmt.Errorf("wait cost must be >= 0, got %v", cost)
+	}
+	s.waitCost = cost
+	return nil
+}
+
+// SetMaxTime caps the search horizon. By default the horizon is the last
+// schedule change plus one step per cell reachable from the goal, after
+// which waiting can no longer help.
+//
+// Params:
+//
+//	t: latest time step the path may reach (must be > 0)
+//
+// Returns:
+//
+//	error: if t is not positive
+func (s *SpaceTimeAStar) SetMaxTime(t int) error {
+	if t <= 0 {
+		return fmt.Errorf("maximum time must be > 0, got %d", t)
+	}
+	s.maxTime = t
+	return nil
+}
+
+// ScheduleObstacle blocks cell (x, y) during time steps tStart through
+// tEnd-1. Use math.MaxInt as tEnd for a cell that closes for good.
+//
+// Params:
+//
+//	x: X coordinate
+//	y: Y coordinate
+//	tStart: first blocked time step (must be >= 0)
+//	tEnd: first time step the cell is free again (must be > tStart)
+//
+// Returns:
+//
+//	error: if the interval is empty or starts before time 0
+func (s *SpaceTimeAStar) ScheduleObstacle(x, y, tStart, tEnd int) error {
+	if tStart < 0 || tEnd <= tStart {
+		return fmt.Errorf("invalid obstacle interval [%d, %d)", tStart, tEnd)
+	}
+
+	key := [2]int{x, y}
+	s.schedule[key] = append(s.schedule[key], interval{tStart, tEnd})
+
+	s.lastEvent = max(s.lastEvent, tStart)
+	if tEnd != math.MaxInt {
+		s.lastEvent = max(s.lastEvent, tEnd)
+	}
+	return nil
+}
+
+// ClearSchedule removes all scheduled obstacles.
+func (s *SpaceTimeAStar) ClearSchedule() {
+	s.schedule = make(map[[2]int][]interval)
+	s.lastEvent = 0
+}
+
+// IsBlocked reports whether cell (x, y) is a scheduled obstacle at time t.
+// Static grid obstacles are not considered.
+//
+// Params:
+//
+//	x: X coordinate
+//	y: Y coordinate
+//	t: time step
+//
+// Returns:
+//
+//	bool: true if a scheduled interval covers t
+func (s *SpaceTimeAStar) IsBlocked(x, y, t int) bool {
+	for _, iv := range s.schedule[[2]int{x, y}] {
+		if t >= iv.start && t < iv.end {
+			return true
+		}
+	}
+	return false
+}
+
+// FindPath finds the cheapest timed path from start (at time 0) to goal.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	TimedPath: one entry per time step from start to the arrival at goal
+//	error: if no path exists within the horizon or invalid input
+func (s *SpaceTimeAStar) FindPath(start, goal *algo.Node) (TimedPath, error) {
+	path, _, err := s.Search(start, goal)
+	return path, err
+}
+
+// Search finds the cheapest timed path and reports its cost.
+//
+// Params:
+//
+//	start: starting node
+//	goal: destination node
+//
+// Returns:
+//
+//	TimedPath: one entry per time step from start to the arrival at goal
+//	float64: total movement and wait cost of the path
+//	error: if no path exists within the horizon or invalid input
+func (s *SpaceTimeAStar) Search(start, goal *algo.Node) (TimedPath, float64, error) {
+	if s.grid == nil {
+		return nil, 0, fmt.Errorf("grid must be set before calling FindPath")
+	}
+
+	if start == nil || goal == nil {
+		return nil, 0, fmt.Errorf("start and goal nodes cannot be nil")
+	}
+
+	if start.IsObstacle || s.IsBlocked(start.X, start.Y, 0) {
+		return nil, 0, fmt.Errorf("start node at (%d, %d) is an obstacle", start.X, start.Y)
+	}
+
+	if goal.IsObstacle {
+		return nil, 0, fmt.Errorf("goal node at (%d, %d) is an obstacle", goal.X, goal.Y)
+	}
+
+	if s.heuristic == nil {
+		return nil, 0, fmt.Errorf("heuristic must be set before calling FindPath")
+	}
+
+	// Scheduled obstacles only ever add to the static ones, so a start
+	// the goal cannot reach on the static grid is never reachable
+	reachable := stepDistances(s.grid, goal)
+	if _, ok := reachable[[2]int{start.X, start.Y}]; !ok {
+		return nil, 0, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+			start.X, start.Y, goal.X, goal.Y)
+	}
+
+	maxTime := s.maxTime
+	if maxTime == 0 {
+		// Once the schedule stops changing, waiting never helps and a
+		// cheapest path visits each reachable cell at most once
+		maxTime = s.lastEvent + len(reachable)
+	}
+
+	problem := &spaceTimeProblem{
+		grid:  s.grid,
+		start: start,
+		goal:  goal,
+		heuristic: func(node *algo.Node) float64 {
+			return s.heuristic(node, goal)
+		},
+		cost: func(from, to *algo.Node) float64 {
+			if from.Equals(to) {
+				return s.waitCost
+			}
+			return s.grid.GetCost(from, to)
+		},
+		blocked: func(from, to *algo.Node, t int) bool {
+			return s.IsBlocked(to.X, to.Y, t)
+		},
+		canFinish: func(int) bool { return true },
+		maxTime:   maxTime,
+		// After the last event every cell is either free or closed for
+		// good, so arrival times past it only differ in cost
+		settledFrom: s.lastEvent + 1,
+	}
+
+	path, cost, _, ok := problem.solve()
+	if !ok {
+		return nil, 0, fmt.Errorf("no path found from (%d, %d) to (%d, %d)",
+			start.X, start.Y, goal.X, goal.Y)
+	}
+	return path, cost, nil
+}
@@ -0,0 +1,290 @@
+package mapf
+
+import (
+	"math"
+	"testing"
+
+	"github.com/edgejay/go-pathfinding/algo"
+)
+
+// newDoorGrid creates a 7x3 grid with a wall in the middle row except for
+// a door at (3, 1), so the short route from (3, 0) to (3, 2) is through
+// the door and the detour goes around the wall's ends
+func newDoorGrid() *algo.Grid {
+	grid, _ := algo.NewGrid(7, 3, algo.FourWay)
+	for x := 1; x < 6; x++ {
+		if x != 3 {
+			grid.SetObstacle(x, 1)
+		}
+	}
+	return grid
+}
+
+// assertTimedPathValid checks timing, adjacency and the schedule
+func assertTimedPathValid(t *testing.T, st *SpaceTimeAStar, grid *algo.Grid, path TimedPath) {
+	t.Helper()
+
+	for i, step := range path {
+		if step.Time != i {
+			t.Fatalf("step %d has time %d", i, step.Time)
+		}
+		if st.IsBlocked(step.Node.X, step.Node.Y, step.Time) {
+			t.Errorf("path occupies scheduled obstacle (%d, %d) at time %d", step.Node.X, step.Node.Y, step.Time)
+		}
+		if i == 0 || step.Node.Equals(path[i-1].Node) {
+			continue
+		}
+		adjacent := false
+		for _, n := range grid.GetNeighbors(path[i-1].Node) {
+			if n.Equals(step.Node) {
+				adjacent = true
+			}
+		}
+		if !adjacent {
+			t.Errorf("illegal move at time %d", step.Time)
+		}
+	}
+}
+
+// waits counts the wait actions in a timed path
+func waits(path TimedPath) int {
+	n := 0
+	for i := 1; i < len(path); i++ {
+		if path[i].Node.Equals(path[i-1].Node) {
+			n++
+		}
+	}
+	return n
+}
+
+// TestSpaceTimeAStar_FindPath_NoSchedule tests that without scheduled
+// obstacles the path is an ordinary shortest path
+func TestSpaceTimeAStar_FindPath_NoSchedule(t *testing.T) {
+	grid := newDoorGrid()
+	start, _ := grid.GetNode(3, 0)
+	goal, _ := grid.GetNode(3, 2)
+
+	st := NewSpaceTimeAStar()
+	st.SetGrid(grid)
+	path, cost, err := st.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search() returned error: %v", err)
+	}
+
+	assertTimedPathValid(t, st, grid, path)
+	if len(path) != 3 || cost != 2 {
+		t.Errorf("Expected 3-step path with cost 2, got %d steps with cost %.1f", len(path), cost)
+	}
+}
+
+// TestSpaceTimeAStar_FindPath_WaitsForDoor tests that the agent waits for
+// a briefly closed door instead of taking the long detour
+func TestSpaceTimeAStar_FindPath_WaitsForDoor(t *testing.T) {
+	grid := newDoorGrid()
+	start, _ := grid.GetNode(3, 0)
+	goal, _ := grid.GetNode(3, 2)
+
+	st := NewSpaceTimeAStar()
+	st.SetGrid(grid)
+	if err := st.ScheduleObstacle(3, 1, 0, 3); err != nil {
+		t.Fatalf("ScheduleObstacle() returned error: %v", err)
+	}
+
+	path, cost, err := st.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search() returned error: %v", err)
+	}
+
+	assertTimedPathValid(t, st, grid, path)
+
+	// Door opens at time 3: wait twice, enter at 3, arrive at 4
+	if cost != 4 || waits(path) != 2 {
+		t.Errorf("Expected cost 4 with 2 waits, got cost %.1f with %d waits", cost, waits(path))
+	}
+	if door, _ := grid.GetNode(3, 1); !path[3].Node.Equals(door) {
+		t.Errorf("Expected to pass the door at time 3, got (%d, %d)", path[3].Node.X, path[3].Node.Y)
+	}
+}
+
+// TestSpaceTimeAStar_FindPath_WaitCost tests that an expensive wait makes
+// the detour cheaper than waiting (or pacing) until the door opens
+func TestSpaceTimeAStar_FindPath_WaitCost(t *testing.T) {
+	grid := newDoorGrid()
+	start, _ := grid.GetNode(3, 0)
+	goal, _ := grid.GetNode(3, 2)
+
+	st := NewSpaceTimeAStar()
+	st.SetGrid(grid)
+	st.ScheduleObstacle(3, 1, 0, 9)
+	if err := st.SetWaitCost(10); err != nil {
+		t.Fatalf("SetWaitCost() returned error: %v", err)
+	}
+
+	path, cost, err := st.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search() returned error: %v", err)
+	}
+
+	assertTimedPathValid(t, st, grid, path)
+	if waits(path) != 0 || pathVisits(path, 3, 1) {
+		t.Errorf("Expected a detour without waiting, got %d waits", waits(path))
+	}
+	// Around the wall's end: 3 across, 2 down, 3 back
+	if cost != 8 {
+		t.Errorf("Expected detour cost 8, got %.1f", cost)
+	}
+}
+
+// TestSpaceTimeAStar_FindPath_DoorClosesForGood tests a door that closes
+// before the agent can reach it and never reopens
+func TestSpaceTimeAStar_FindPath_DoorClosesForGood(t *testing.T) {
+	grid := newDoorGrid()
+	start, _ := grid.GetNode(3, 0)
+	goal, _ := grid.GetNode(3, 2)
+
+	st := NewSpaceTimeAStar()
+	st.SetGrid(grid)
+	st.ScheduleObstacle(3, 1, 1, math.MaxInt)
+
+	path, cost, err := st.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search() returned error: %v", err)
+	}
+
+	assertTimedPathValid(t, st, grid, path)
+	if cost != 8 {
+		t.Errorf("Expected detour cost 8, got %.1f", cost)
+	}
+}
+
+// TestSpaceTimeAStar_FindPath_Patrol tests timing a crossing around a
+// patrol sweeping back and forth along the agent's row
+func TestSpaceTimeAStar_FindPath_Patrol(t *testing.T) {
+	grid, _ := algo.NewGrid(6, 1, algo.FourWay)
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(5, 0)
+
+	st := NewSpaceTimeAStar()
+	st.SetGrid(grid)
+	// The patrol stands on (3, 0) and (4, 0) until time 6
+	for x := 3; x <= 4; x++ {
+		st.ScheduleObstacle(x, 0, 0, 6)
+	}
+
+	path, cost, err := st.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search() returned error: %v", err)
+	}
+
+	assertTimedPathValid(t, st, grid, path)
+
+	// Reach (2, 0) at time 2, wait until (3, 0) frees at 6, arrive at 8
+	if len(path) != 9 || cost != 8 {
+		t.Errorf("Expected arrival at time 8 with cost 8, got time %d with cost %.1f", len(path)-1, cost)
+	}
+}
+
+// TestSpaceTimeAStar_FindPath_NoPath tests failure when the goal is cut
+// off for good
+func TestSpaceTimeAStar_FindPath_NoPath(t *testing.T) {
+	grid, _ := algo.NewGrid(4, 1, algo.FourWay)
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(3, 0)
+
+	st := NewSpaceTimeAStar()
+	st.SetGrid(grid)
+	st.ScheduleObstacle(2, 0, 0, math.MaxInt)
+
+	if _, err := st.FindPath(start, goal); err == nil {
+		t.Error("FindPath() should return error when the goal is cut off")
+	}
+}
+
+// TestSpaceTimeAStar_FindPath_Unreachable tests that goals cut off by
+// static or permanently closed cells fail fast on a large grid, including
+// with free waits that could otherwise fill every time layer
+func TestSpaceTimeAStar_FindPath_Unreachable(t *testing.T) {
+	grid, _ := algo.NewGrid(100, 100, algo.FourWay)
+	start, _ := grid.GetNode(0, 0)
+	goal, _ := grid.GetNode(99, 99)
+	grid.SetObstacle(98, 99)
+	grid.SetObstacle(99, 98)
+
+	st := NewSpaceTimeAStar()
+	st.SetGrid(grid)
+	if _, err := st.FindPath(start, goal); err == nil {
+		t.Error("FindPath() should return error for a walled-in goal")
+	}
+
+	grid.ClearObstacle(99, 98)
+	st.ScheduleObstacle(99, 98, 5, math.MaxInt)
+	st.SetWaitCost(0)
+	if _, err := st.FindPath(start, goal); err == nil {
+		t.Error("FindPath() should return error when the last opening closes for good")
+	}
+
+	// Free waits still find the shortest route once the schedule settles
+	st.ClearSchedule()
+	st.ScheduleObstacle(99, 98, 0, 300)
+	path, cost, err := st.Search(start, goal)
+	if err != nil {
+		t.Fatalf("Search() returned error: %v", err)
+	}
+	assertTimedPathValid(t, st, grid, path)
+	if cost != 198 || path[len(path)-1].Time < 300 {
+		t.Errorf("Expected cost 198 arriving after time 300, got cost %.1f at time %d", cost, path[len(path)-1].Time)
+	}
+}
+
+// TestSpaceTimeAStar_FindPath_InputValidation tests error handling for invalid inputs
+func TestSpaceTimeAStar_FindPath_InputValidation(t *testing.T) {
+	st := NewSpaceTimeAStar()
+	grid, _ := algo.NewGrid(5, 5, algo.FourWay)
+	node, _ := grid.GetNode(0, 0)
+	other, _ := grid.GetNode(4, 4)
+
+	if _, err := st.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when grid is not set")
+	}
+
+	st.SetGrid(grid)
+	if _, err := st.FindPath(nil, other); err == nil {
+		t.Error("FindPath() should return error for nil start")
+	}
+
+	if err := st.SetWaitCost(-1); err == nil {
+		t.Error("SetWaitCost(-1) should return error")
+	}
+	if err := st.SetMaxTime(0); err == nil {
+		t.Error("SetMaxTime(0) should return error")
+	}
+	if err := st.ScheduleObstacle(1, 1, 3, 3); err == nil {
+		t.Error("ScheduleObstacle() should return error for an empty interval")
+	}
+	if err := st.ScheduleObstacle(1, 1, -1, 3); err == nil {
+		t.Error("ScheduleObstacle() should return error for a negative start")
+	}
+
+	st.SetHeuristic(nil)
+	if _, err := st.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when heuristic is nil")
+	}
+	st.SetHeuristic(algo.Manhattan)
+
+	st.ScheduleObstacle(0, 0, 0, 2)
+	if _, err := st.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when start is blocked at time 0")
+	}
+
+	st.ClearSchedule()
+	if st.IsBlocked(0, 0, 0) {
+		t.Error("ClearSchedule() should remove scheduled obstacles")
+	}
+
+	if err := st.SetMaxTime(3); err != nil {
+		t.Fatalf("SetMaxTime() returned error: %v", err)
+	}
+	if _, err := st.FindPath(node, other); err == nil {
+		t.Error("FindPath() should return error when the goal is beyond the horizon")
+	}
+}
@@ -0,0 +1,312 @@
+package mapf
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/edgejay/go-pathfinding/algo"
+)
+
+// reservationTable records which agent occupies each cell at each time step.
+type reservationTable map[spaceTimeState]int
+
+// reserve records every step of an agent's plan.
+func (r reservationTable) reserve(agent int, path TimedPath) {
+	for _, step := range path {
+		r[spaceTimeState{step.Node.X, step.Node.Y, step.Time}] = agent
+	}
+}
+
+// blocks reports whether moving from one cell to another, arriving at
+// time t, collides with a reservation: either the destination is taken,
+// or another agent moves the opposite way during the same step.
+func (r reservationTable) blocks(from, to *algo.Node, t int) bool {
+	if _, taken := r[spaceTimeState{to.X, to.Y, t}]; taken {
+		return true
+	}
+	if from.Equals(to) {
+		return false
+	}
+	entering, ok := r[spaceTimeState{from.X, from.Y, t}]
+	if !ok {
+		return false
+	}
+	leaving, ok := r[spaceTimeState{to.X, to.Y, t - 1}]
+	return ok && leaving == entering
+}
+
+// WHCAStar implements Windowed Hierarchical Cooperative A* (Silver, 2005),
+// a fast, non-optimal alternative to CBS.
+//
+// Agents plan one at a time in priority order (their order in the agents
+// slice, except that an agent boxed in by higher-priority plans is moved
+// to the front for that window). Each plans a space-time path over the next window steps that
+// avoids the cells already reserved in a shared reservation table, then
+// reserves its own path. Beyond the window, reservations are ignored and an
+// abstract distance estimates the remaining cost: by default the true
+// distance to the goal ignoring other agents (the "hierarchical" part),
+// taken from a flow field per goal. All agents execute half a window and
+// then replan from their new positions, so the window slides forward until
+// every agent is at its goal.
+//
+// Moves cost Grid.GetCost, waiting costs 1 (0 on the agent's goal), and
+// every action takes one time step. Returned paths never collide, but
+// WHCA* is incomplete: agents may deadlock in narrow corridors, in which
+// case Solve gives up after a maximum number of time steps.
+type WHCAStar struct {
+	// grid is the shared search space
+	grid algo.GridInterface
+
+	// heuristic replaces the true-distance abstraction if set
+	heuristic algo.HeuristicFunc
+
+	// window is the number of time steps each agent plans ahead
+	window int
+
+	// maxSteps caps the length of the simulated plan
+	maxSteps int
+}
+
+// NewWHCAStar creates a new WHCA* solver.
+// By default, it uses true distances beyond the window, a window of 8
+// steps and gives up after 1000 time steps.
+//
+// Returns:
+//
+//	*WHCAStar: new WHCA* solver
+func NewWHCAStar() *WHCAStar {
+	return &WHCAStar{
+		window:   8,
+		maxSteps: 1000,
+	}
+}
+
+// SetGrid configures the grid shared by all agents.
+//
+// Params:
+//
+//	grid: grid to plan on
+func (w *WHCAStar) SetGrid(grid algo.GridInterface) {
+	w.grid = grid
+}
+
+// SetHeuristic replaces the true-distance abstraction with a plain
+// heuristic, which is cheaper to set up on large grids but blind to
+// obstacles, so agents are more likely to livelock behind walls.
+//
+// Params:
+//
+//	heuristic: heuristic function to use, or nil for true distances
+func (w *WHCAStar) SetHeuristic(heuristic algo.HeuristicFunc) {
+	w.heuristic = heuristic
+}
+
+// SetWindow configures how many time steps each agent plans ahead.
+// Larger windows resolve more interactions at a higher planning cost; a
+// window of 1 is greedy and easily stalls when two agents meet head-on.
+//
+// Params:
+//
+//	window: window size in time steps (must be > 0)
+//
+// Returns:
+//
+//	error: if window is not positive
+func (w *WHCAStar) SetWindow(window int) error {
+	if window <= 0 {
+		return fmt.Errorf("window must be > 0, got %d", window)
+	}
+	w.window = window
+	return nil
+}
+
+// SetMaxSteps caps how many time steps Solve simulates before giving up.
+//
+// Params:
+//
+//	n: maximum number of time steps (must be > 0)
+//
+// Returns:
+//
+//	error: if n is not positive
+func (w *WHCAStar) SetMaxSteps(n int) error {
+	if n <= 0 {
+		return fmt.Errorf("maximum steps must be > 0, got %d", n)
+	}
+	w.maxSteps = n
+	return nil
+}
+
+// Solve plans collision-free timed paths for all agents.
+//
+// Params:
+//
+//	agents: agents in priority order, with distinct starts and distinct goals
+//
+// Returns:
+//
+//	*Solution: per-agent timed paths and their costs
+//	error: if input is invalid, an agent gets stuck, or not all agents arrive within the step limit
+func (w *WHCAStar) Solve(agents []Agent) (*Solution, error) {
+	if err := validateAgents(w.grid, agents); err != nil {
+		return nil, err
+	}
+
+	distance := w.heuristic
+	if distance == nil {
+		fields := make(map[[2]int]*algo.FlowField)
+		for i, agent := range agents {
+			field, err := algo.NewFlowField(w.grid, agent.Goal)
+			if err != nil {
+				return nil, err
+			}
+			if math.IsInf(field.Cost(agent.Start.X, agent.Start.Y), 1) {
+				return nil, fmt.Errorf("agent %d: no path found from (%d, %d) to (%d, %d)",
+					i, agent.Start.X, agent.Start.Y, agent.Goal.X, agent.Goal.Y)
+			}
+			fields[[2]int{agent.Goal.X, agent.Goal.Y}] = field
+		}
+		distance = func(node, goal *algo.Node) float64 {
+			return fields[[2]int{goal.X, goal.Y}].Cost(node.X, node.Y)
+		}
+	}
+
+	positions := make([]*algo.Node, len(agents))
+	paths := make([]TimedPath, len(agents))
+	for i, agent := range agents {
+		positions[i] = agent.Start
+		paths[i] = TimedPath{{Node: agent.Start, Time: 0}}
+	}
+
+	expanded := 0
+	for t := 0; !allArrived(positions, agents); {
+		if t >= w.maxSteps {
+			return nil, fmt.Errorf("agents did not reach their goals within %d time steps", w.maxSteps)
+		}
+
+		plans, n, err := w.planWindow(agents, positions, t, distance)
+		expanded += n
+		if err != nil {
+			return nil, err
+		}
+
+		// Execute half of the window, then replan
+		steps := max(1, w.window/2)
+		for s := 1; s <= steps; s++ {
+			for i, plan := range plans {
+				positions[i] = plan.At(t + s)
+				paths[i] = append(paths[i], TimedNode{Node: positions[i], Time: t + s})
+			}
+		}
+		t += steps
+	}
+
+	// Drop trailing waits on the goal so each path ends at the final arrival
+	for i, agent := range agents {
+		for len(paths[i]) > 1 && paths[i][len(paths[i])-2].Node.Equals(agent.Goal) {
+			paths[i] = paths[i][:len(paths[i])-1]
+		}
+	}
+
+	return newSolution(paths, expanded), nil
+}
+
+// planWindow plans every agent's next window from the given positions at
+// time t, estimating the cost beyond it with distance. Agents plan in
+// priority order; an agent left without any collision-free move is
+// promoted to the front and the window is planned again, up to once per
+// agent.
+//
+// Returns:
+//
+//	[]TimedPath: each agent's windowed plan starting at time t
+//	int: number of expanded space-time states
+//	error: if no order lets every agent plan
+func (w *WHCAStar) planWindow(agents []Agent, positions []*algo.Node, t int, distance algo.HeuristicFunc) ([]TimedPath, int, error) {
+	order := make([]int, len(agents))
+	for i := range order {
+		order[i] = i
+	}
+
+	expanded := 0
+	for attempt := 0; ; attempt++ {
+		plans, n, stuck := w.planInOrder(agents, positions, t, distance, order)
+		expanded += n
+		if stuck < 0 {
+			return plans, expanded, nil
+		}
+		if order[0] == stuck || attempt == len(agents) {
+			return nil, expanded, fmt.Errorf("agent %d is stuck at (%d, %d) at time %d",
+				stuck, positions[stuck].X, positions[stuck].Y, t)
+		}
+
+		for i, agent := range order {
+			if agent == stuck {
+				copy(order[1:i+1], order[:i])
+				order[0] = stuck
+				break
+			}
+		}
+	}
+}
+
+// planInOrder plans each agent's window in the given order, reserving
+// every plan before the next agent searches.
+//
+// Returns:
+//
+//	[]TimedPath: each agent's windowed plan, indexed like agents
+//	int: number of expanded space-time states
+//	int: index of the first agent without a plan, or -1 if all succeeded
+func (w *WHCAStar) planInOrder(agents []Agent, positions []*algo.Node, t int, distance algo.HeuristicFunc, order []int) ([]TimedPath, int, int) {
+	table := make(reservationTable)
+	plans := make([]TimedPath, len(agents))
+	expanded := 0
+
+	for _, i := range order {
+		goal := agents[i].Goal
+		problem := &spaceTimeProblem{
+			grid:      w.grid,
+			start:     positions[i],
+			goal:      goal,
+			startTime: t,
+			heuristic: func(node *algo.Node) float64 {
+				return distance(node, goal)
+			},
+			cost: func(from, to *algo.Node) float64 {
+				if !from.Equals(to) {
+					return w.grid.GetCost(from, to)
+				}
+				if from.Equals(goal) {
+					return 0
+				}
+				return 1
+			},
+			blocked:   table.blocks,
+			canFinish: func(int) bool { return false },
+			maxTime:   t + w.window,
+			windowEnd: t + w.window,
+		}
+
+		plan, _, n, ok := problem.solve()
+		expanded += n
+		if !ok {
+			return nil, expanded, i
+		}
+
+		table.reserve(i, plan)
+		plans[i] = plan
+	}
+
+	return plans, expanded, -1
+}
+
+// allArrived reports whether every agent stands on its goal.
+func allArrived(positions []*algo.Node, agents []Agent) bool {
+	for i, agent := range agents {
+		if !positions[i].Equals(agent.Goal) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,177 @@
+package mapf
+
+import (
+	"testing"
+
+	"github.com/edgejay/go-pathfinding/algo"
+)
+
+// TestWHCAStar_Solve_Crossing tests two agents whose shortest paths cross
+func TestWHCAStar_Solve_Crossing(t *testing.T) {
+	grid, _ := algo.NewGrid(3, 3, algo.FourWay)
+	agents := []Agent{
+		agentAt(grid, 0, 1, 2, 1),
+		agentAt(grid, 1, 0, 1, 2),
+	}
+
+	whca := NewWHCAStar()
+	whca.SetGrid(grid)
+	solution, err := whca.Solve(agents)
+	if err != nil {
+		t.Fatalf("Solve() returned error: %v", err)
+	}
+
+	assertValidSolution(t, grid, agents, solution)
+
+	// The higher-priority agent goes straight through
+	if got := solution.Paths[0][len(solution.Paths[0])-1].Time; got != 2 {
+		t.Errorf("Expected agent 0 to arrive at time 2, got %d", got)
+	}
+	if solution.SumOfCosts < 5 {
+		t.Errorf("Sum of costs %d is below the optimum 5", solution.SumOfCosts)
+	}
+}
+
+// TestWHCAStar_Solve_Windows tests that every window size yields valid plans
+func TestWHCAStar_Solve_Windows(t *testing.T) {
+	grid, _ := algo.NewGrid(8, 8, algo.FourWay)
+	grid.SetObstacle(3, 3)
+	grid.SetObstacle(4, 4)
+	agents := []Agent{
+		agentAt(grid, 0, 0, 7, 7),
+		agentAt(grid, 7, 7, 0, 0),
+		agentAt(grid, 0, 7, 7, 0),
+		agentAt(grid, 7, 0, 0, 7),
+		agentAt(grid, 3, 0, 3, 7),
+	}
+
+	for _, window := range []int{2, 4, 8, 16} {
+		whca := NewWHCAStar()
+		whca.SetGrid(grid)
+		if err := whca.SetWindow(window); err != nil {
+			t.Fatalf("SetWindow(%d) returned error: %v", window, err)
+		}
+
+		solution, err := whca.Solve(agents)
+		if err != nil {
+			t.Fatalf("window %d: Solve() returned error: %v", window, err)
+		}
+
+		assertValidSolution(t, grid, agents, solution)
+		if solution.SumOfCosts < 14*4+7 {
+			t.Errorf("window %d: sum of costs %d is below the lower bound %d", window, solution.SumOfCosts, 14*4+7)
+		}
+	}
+}
+
+// TestWHCAStar_Solve_EightWay tests diagonal movement with the octile heuristic
+func TestWHCAStar_Solve_EightWay(t *testing.T) {
+	grid, _ := algo.NewGrid(6, 6, algo.EightWay)
+	agents := []Agent{
+		agentAt(grid, 0, 0, 5, 5),
+		agentAt(grid, 5, 5, 0, 0),
+		agentAt(grid, 0, 5, 5, 0),
+		agentAt(grid, 5, 0, 0, 5),
+	}
+
+	whca := NewWHCAStar()
+	whca.SetGrid(grid)
+	whca.SetHeuristic(algo.Diagonal)
+	solution, err := whca.Solve(agents)
+	if err != nil {
+		t.Fatalf("Solve() returned error: %v", err)
+	}
+
+	assertValidSolution(t, grid, agents, solution)
+}
+
+// TestWHCAStar_Solve_MovesAsideAtGoal tests that an agent resting on its goal
+// steps aside for a higher-priority agent and then returns
+func TestWHCAStar_Solve_MovesAsideAtGoal(t *testing.T) {
+	grid, _ := algo.NewGrid(5, 2, algo.FourWay)
+	for x := 0; x < 5; x++ {
+		if x != 2 {
+			grid.SetObstacle(x, 1)
+		}
+	}
+	agents := []Agent{
+		agentAt(grid, 0, 0, 4, 0),
+		agentAt(grid, 2, 0, 2, 0),
+	}
+
+	whca := NewWHCAStar()
+	whca.SetGrid(grid)
+	solution, err := whca.Solve(agents)
+	if err != nil {
+		t.Fatalf("Solve() returned error: %v", err)
+	}
+
+	assertValidSolution(t, grid, agents, solution)
+
+	if !pathVisits(solution.Paths[1], 2, 1) {
+		t.Error("Expected agent 1 to step into the side pocket")
+	}
+}
+
+// TestWHCAStar_Solve_Deadlock tests that an impossible swap hits the step limit
+func TestWHCAStar_Solve_Deadlock(t *testing.T) {
+	grid, _ := algo.NewGrid(3, 1, algo.FourWay)
+	agents := []Agent{
+		agentAt(grid, 0, 0, 2, 0),
+		agentAt(grid, 2, 0, 0, 0),
+	}
+
+	whca := NewWHCAStar()
+	whca.SetGrid(grid)
+	if err := whca.SetMaxSteps(40); err != nil {
+		t.Fatalf("SetMaxSteps() returned error: %v", err)
+	}
+
+	if _, err := whca.Solve(agents); err == nil {
+		t.Error("Solve() should fail for an impossible corridor swap")
+	}
+}
+
+// TestWHCAStar_Solve_InputValidation tests error handling for invalid inputs
+func TestWHCAStar_Solve_InputValidation(t *testing.T) {
+	whca := NewWHCAStar()
+	grid, _ := algo.NewGrid(4, 4, algo.FourWay)
+
+	if _, err := whca.Solve([]Agent{agentAt(grid, 0, 0, 1, 1)}); err == nil {
+		t.Error("Solve() should return error when grid is not set")
+	}
+
+	whca.SetGrid(grid)
+	if err := whca.SetWindow(0); err == nil {
+		t.Error("SetWindow(0) should return error")
+	}
+	if err := whca.SetMaxSteps(-1); err == nil {
+		t.Error("SetMaxSteps(-1) should return error")
+	}
+
+	if _, err := whca.Solve([]Agent{agentAt(grid, 0, 0, 3, 3), agentAt(grid, 1, 0, 3, 3)}); err == nil {
+		t.Error("Solve() should return error for shared goals")
+	}
+
+	solution, err := whca.Solve([]Agent{agentAt(grid, 1, 1, 1, 1)})
+	if err != nil || len(solution.Paths[0]) != 1 || solution.SumOfCosts != 0 {
+		t.Errorf("Expected single-step path for an agent on its goal, got %v (err=%v)", solution, err)
+	}
+
+	for y := 0; y < 4; y++ {
+		grid.SetObstacle(2, y)
+	}
+	if _, err := whca.Solve([]Agent{agentAt(grid, 0, 0, 3, 3)}); err == nil {
+		t.Error("Solve() should return error when a goal is unreachable")
+	}
+}
+
+// pathVisits reports whether a timed path ever occupies (x, y)
+func pathVisits(path TimedPath, x, y int) bool {
+	for _, step := range path {
+		if step.Node.X == x && step.Node.Y == y {
+			return true
+		}
+	}
+	return false
+}
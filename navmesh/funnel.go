@@ -0,0 +1,75 @@
+package navmesh
+
+// Funnel pulls a taut path through a corridor of portals with the simple
+// stupid funnel algorithm (Mononen, 2010).
+//
+// The funnel is a wedge from the current apex through the left and right
+// endpoints of the portals seen so far. Each portal narrows the wedge from
+// either side; when one side would cross over the other, the crossed-over
+// endpoint is a corner of the path, becomes the new apex, and the scan
+// restarts from the portal after it. The result is the shortest path
+// through the portals, turning only at portal endpoints.
+//
+// Params:
+//
+//	start: starting point, inside the first polygon of the corridor
+//	goal: destination point, inside the last polygon of the corridor
+//	lefts, rights: endpoints of each portal on the left and right of the
+//	direction of travel
+//
+// Returns:
+//
+//	[]Point: path from start to goal through the turning points
+func Funnel(start, goal Point, lefts, rights []Point) []Point {
+	// The goal is a final, zero-width portal
+	lefts = append(append([]Point(nil), lefts...), goal)
+	rights = append(append([]Point(nil), rights...), goal)
+
+	path := []Point{start}
+	apex, left, right := start, start, start
+	apexIndex, leftIndex, rightIndex := -1, -1, -1
+
+	for i := 0; i < len(lefts); i++ {
+		l, r := lefts[i], rights[i]
+
+		// Narrow the right side if r is inside the funnel
+		if cross(apex, right, r) >= 0 {
+			if apex == right || cross(apex, left, r) < 0 {
+				right, rightIndex = r, i
+			} else {
+				// r crosses the left side: the left endpoint is a corner
+				path = appendPoint(path, left)
+				apex, apexIndex = left, leftIndex
+				left, right = apex, apex
+				leftIndex, rightIndex = apexIndex, apexIndex
+				i = apexIndex
+				continue
+			}
+		}
+
+		// Narrow the left side if l is inside the funnel
+		if cross(apex, left, l) <= 0 {
+			if apex == left || cross(apex, right, l) > 0 {
+				left, leftIndex = l, i
+			} else {
+				// l crosses the right side: the right endpoint is a corner
+				path = appendPoint(path, right)
+				apex, apexIndex = right, rightIndex
+				left, right = apex, apex
+				leftIndex, rightIndex = apexIndex, apexIndex
+				i = apexIndex
+				continue
+			}
+		}
+	}
+
+	return appendPoint(path, goal)
+}
+
+// appendPoint appends a point to a path unless it repeats the last one.
+func appendPoint(path []Point, p Point) []Point {
+	if len(path) > 0 && path[len(path)-1] == p {
+		return path
+	}
+	return append(path, p)
+}
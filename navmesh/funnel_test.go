@@ -0,0 +1,60 @@
+package navmesh
+
+import (
+	"testing"
+)
+
+func TestFunnel(t *testing.T) {
+	tests := []struct {
+		name        string
+		start, goal Point
+		lefts       []Point
+		rights      []Point
+		want        []Point
+	}{
+		{
+			name:  "no portals",
+			start: Point{0, 0}, goal: Point{3, 4},
+			want: []Point{{0, 0}, {3, 4}},
+		},
+		{
+			// Walking up the y axis, left is towards negative x
+			name:  "straight corridor",
+			start: Point{0, 0}, goal: Point{0, 4},
+			lefts:  []Point{{-1, 1}, {-1, 2}, {-1, 3}},
+			rights: []Point{{1, 1}, {1, 2}, {1, 3}},
+			want:   []Point{{0, 0}, {0, 4}},
+		},
+		{
+			// The corridor bends right around the corner (1, 2)
+			name:  "turn around a corner",
+			start: Point{0.5, 0}, goal: Point{3, 2.5},
+			lefts:  []Point{{0, 1}, {0, 2}, {1, 3}},
+			rights: []Point{{1, 1}, {1, 2}, {1, 2}},
+			want:   []Point{{0.5, 0}, {1, 2}, {3, 2.5}},
+		},
+		{
+			// The first portal is passed straight; the path turns left at
+			// the end of the second
+			name:  "turn at a later portal",
+			start: Point{0.5, 0}, goal: Point{-3, 5},
+			lefts:  []Point{{0, 2}, {0, 4}},
+			rights: []Point{{1, 2}, {1, 4}},
+			want:   []Point{{0.5, 0}, {0, 4}, {-3, 5}},
+		},
+	}
+
+	for _, tt := range tests {
+		got := Funnel(tt.start, tt.goal, tt.lefts, tt.rights)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: Funnel() = %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: Funnel() = %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
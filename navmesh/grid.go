@@ -0,0 +1,98 @@
+package navmesh
+
+import (
+	"errors"
+
+	"github.com/edgejay/go-pathfinding/algo"
+)
+
+// FromGrid builds a navigation mesh covering the walkable cells of a grid.
+//
+// Cell (x, y) covers the unit square from (x, y) to (x+1, y+1). Walkable
+// cells are merged greedily into rectangles: each rectangle grows right
+// along its first row as far as possible, then down while the whole span
+// below is walkable and unclaimed. Open areas therefore collapse into a few
+// polygons. Rectangles that touch only at a corner are not connected, so
+// paths never squeeze diagonally between two obstacles. Cell costs and the
+// grid's movement type are ignored; movement on the mesh is free-form.
+//
+// Params:
+//
+//	grid: grid whose walkable cells to cover
+//
+// Returns:
+//
+//	*NavMesh: navigation mesh of rectangles
+//	error: if the grid is nil or has no walkable cells
+func FromGrid(grid *algo.Grid) (*NavMesh, error) {
+	if grid == nil {
+		return nil, errors.New("grid cannot be nil")
+	}
+
+	claimed := make([][]bool, grid.Height)
+	for y := range claimed {
+		claimed[y] = make([]bool, grid.Width)
+	}
+	free := func(x, y int) bool {
+		return !grid.IsObstacle(x, y) && !claimed[y][x]
+	}
+
+	var polygons [][]Point
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			if !free(x, y) {
+				continue
+			}
+
+			x1 := x + 1
+			for x1 < grid.Width && free(x1, y) {
+				x1++
+			}
+			y1 := y + 1
+			for y1 < grid.Height && rowFree(free, x, x1, y1) {
+				y1++
+			}
+
+			for cy := y; cy < y1; cy++ {
+				for cx := x; cx < x1; cx++ {
+					claimed[cy][cx] = true
+				}
+			}
+			polygons = append(polygons, []Point{
+				{float64(x), float64(y)},
+				{float64(x1), float64(y)},
+				{float64(x1), float64(y1)},
+				{float64(x), float64(y1)},
+			})
+		}
+	}
+
+	if len(polygons) == 0 {
+		return nil, errors.New("grid has no walkable cells")
+	}
+	return NewNavMesh(polygons)
+}
+
+// rowFree reports whether cells x0 through x1-1 of row y are all free.
+func rowFree(free func(x, y int) bool, x0, x1, y int) bool {
+	for x := x0; x < x1; x++ {
+		if !free(x, y) {
+			return false
+		}
+	}
+	return true
+}
+
+// CellCenter returns the point at the center of a grid cell, for use as a
+// start or goal on a mesh built by FromGrid.
+//
+// Params:
+//
+//	node: grid node
+//
+// Returns:
+//
+//	Point: center of the node's cell
+func CellCenter(node *algo.Node) Point {
+	return Point{float64(node.X) + 0.5, float64(node.Y) + 0.5}
+}
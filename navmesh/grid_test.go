@@ -0,0 +1,123 @@
+package navmesh
+
+import (
+	"testing"
+
+	"github.com/edgejay/go-pathfinding/algo"
+)
+
+func TestFromGrid(t *testing.T) {
+	if _, err := FromGrid(nil); err == nil {
+		t.Error("Expected error for nil grid")
+	}
+
+	walls, _ := algo.NewGrid(2, 2, algo.FourWay)
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			walls.SetObstacle(x, y)
+		}
+	}
+	if _, err := FromGrid(walls); err == nil {
+		t.Error("Expected error for a grid without walkable cells")
+	}
+
+	open, _ := algo.NewGrid(30, 20, algo.EightWay)
+	mesh, err := FromGrid(open)
+	if err != nil {
+		t.Fatalf("FromGrid returned error: %v", err)
+	}
+	if mesh.PolygonCount() != 1 {
+		t.Errorf("Expected an open grid to become 1 polygon, got %d", mesh.PolygonCount())
+	}
+}
+
+// TestFromGrid_Coverage checks that the rectangles cover exactly the
+// walkable cells
+func TestFromGrid_Coverage(t *testing.T) {
+	grid, _ := algo.NewGrid(12, 10, algo.FourWay)
+	for y := 0; y < 7; y++ {
+		grid.SetObstacle(4, y)
+	}
+	for x := 6; x < 11; x++ {
+		grid.SetObstacle(x, 5)
+	}
+	grid.SetObstacle(9, 1)
+
+	mesh, err := FromGrid(grid)
+	if err != nil {
+		t.Fatalf("FromGrid returned error: %v", err)
+	}
+
+	area := 0.0
+	for id := 0; id < mesh.PolygonCount(); id++ {
+		poly, _ := mesh.Polygon(id)
+		box := boundingBox(poly.Vertices)
+		area += (box[2] - box[0]) * (box[3] - box[1])
+	}
+
+	walkable := 0
+	for y := 0; y < grid.Height; y++ {
+		for x := 0; x < grid.Width; x++ {
+			_, inside := mesh.Locate(Point{float64(x) + 0.5, float64(y) + 0.5})
+			if inside == grid.IsObstacle(x, y) {
+				t.Errorf("Cell (%d, %d): obstacle=%v but inside mesh=%v", x, y, grid.IsObstacle(x, y), inside)
+			}
+			if !grid.IsObstacle(x, y) {
+				walkable++
+			}
+		}
+	}
+	if area != float64(walkable) {
+		t.Errorf("Rectangles cover area %.0f, expected %d walkable cells", area, walkable)
+	}
+}
+
+// TestFromGrid_Path checks that mesh paths stay on walkable cells and are
+// no longer than 8-way grid paths
+func TestFromGrid_Path(t *testing.T) {
+	grid, _ := algo.NewGrid(20, 15, algo.EightWay)
+	for y := 0; y < 11; y++ {
+		grid.SetObstacle(6, y)
+	}
+	for y := 4; y < 15; y++ {
+		grid.SetObstacle(13, y)
+	}
+
+	mesh, err := FromGrid(grid)
+	if err != nil {
+		t.Fatalf("FromGrid returned error: %v", err)
+	}
+
+	start, _ := grid.GetNode(1, 1)
+	goal, _ := grid.GetNode(18, 13)
+	result, err := mesh.Search(CellCenter(start), CellCenter(goal))
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+
+	// Sample every segment and check it never enters an obstacle cell
+	for i := 1; i < len(result.Path); i++ {
+		a, b := result.Path[i-1], result.Path[i]
+		for s := 0; s <= 100; s++ {
+			f := float64(s) / 100
+			p := Point{a.X + f*(b.X-a.X), a.Y + f*(b.Y-a.Y)}
+			if _, inside := mesh.Locate(p); !inside {
+				t.Fatalf("Segment %v -> %v leaves the walkable area at %v", a, b, p)
+			}
+		}
+	}
+
+	astar := algo.NewAStar()
+	astar.SetGrid(grid)
+	astar.SetHeuristic(algo.DiagonalWithCost)
+	gridResult, err := astar.Search(start, goal)
+	if err != nil {
+		t.Fatalf("A* Search returned error: %v", err)
+	}
+
+	straight := CellCenter(start).Dist(CellCenter(goal))
+	if result.Cost < straight-1e-9 || result.Cost > gridResult.Cost {
+		t.Errorf("Mesh path cost %.3f should lie between the straight line %.3f and the grid path %.3f",
+			result.Cost, straight, gridResult.Cost)
+	}
+}
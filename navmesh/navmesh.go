@@ -0,0 +1,424 @@
+// Package navmesh provides pathfinding on navigation meshes.
+//
+// A navigation mesh covers the walkable area of a level with convex
+// polygons. Polygons that share part of an edge are adjacent, and the
+// shared segment is the portal between them. Since any two points of a
+// convex polygon see each other, a path only has to choose which portals
+// to cross: A* finds a corridor of polygons, and the funnel algorithm pulls
+// a taut path of points through it. Large open areas need only a few
+// polygons, where a grid would need thousands of cells.
+//
+// FromGrid derives a navigation mesh from the walkable cells of an
+// algo.Grid.
+package navmesh
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// epsilon is the tolerance for geometric comparisons.
+const epsilon = 1e-9
+
+// Point is a position in the plane.
+type Point struct {
+	X, Y float64
+}
+
+// Dist returns the Euclidean distance between two points.
+//
+// Params:
+//
+//	other: point to measure to
+//
+// Returns:
+//
+//	float64: distance between the points
+func (p Point) Dist(other Point) float64 {
+	return math.Hypot(other.X-p.X, other.Y-p.Y)
+}
+
+// Polygon is a convex polygon of the mesh.
+type Polygon struct {
+	// Vertices in counter-clockwise order (positive signed area), corners only
+	Vertices []Point
+
+	// portals lists the indices of the portals on the polygon's boundary
+	portals []int
+}
+
+// Centroid returns the average of the polygon's vertices, which lies
+// inside the polygon.
+//
+// Returns:
+//
+//	Point: centroid of the vertices
+func (p *Polygon) Centroid() Point {
+	var c Point
+	for _, v := range p.Vertices {
+		c.X += v.X
+		c.Y += v.Y
+	}
+	n := float64(len(p.Vertices))
+	return Point{c.X / n, c.Y / n}
+}
+
+// Contains reports whether a point lies inside the polygon or on its
+// boundary.
+//
+// Params:
+//
+//	point: point to test
+//
+// Returns:
+//
+//	bool: true if the point is inside or on the boundary
+func (p *Polygon) Contains(point Point) bool {
+	for i, a := range p.Vertices {
+		b := p.Vertices[(i+1)%len(p.Vertices)]
+		if cross(a, b, point) < -epsilon*a.Dist(b) {
+			return false
+		}
+	}
+	return true
+}
+
+// portal is the segment shared by two adjacent polygons.
+type portal struct {
+	// a and b are the endpoints, ordered along the boundary of polys[0]
+	a, b Point
+
+	// polys are the indices of the two polygons the portal connects
+	polys [2]int
+}
+
+// midpoint returns the center of the portal.
+func (p *portal) midpoint() Point {
+	return Point{(p.a.X + p.b.X) / 2, (p.a.Y + p.b.Y) / 2}
+}
+
+// other returns the polygon on the far side of the portal from poly.
+func (p *portal) other(poly int) int {
+	if p.polys[0] == poly {
+		return p.polys[1]
+	}
+	return p.polys[0]
+}
+
+// crossing returns the portal endpoints on the left and right of an agent
+// walking from polygon from into the other polygon.
+func (p *portal) crossing(from int) (left, right Point) {
+	// Walking out of polys[0], whose interior is left of a -> b, the
+	// endpoint b is on the left
+	if p.polys[0] == from {
+		return p.b, p.a
+	}
+	return p.a, p.b
+}
+
+// NavMesh is a navigation mesh of convex polygons connected by portals.
+type NavMesh struct {
+	// polygons of the mesh, indexed by polygon ID
+	polygons []*Polygon
+
+	// portals between adjacent polygons
+	portals []portal
+}
+
+// NewNavMesh creates a navigation mesh from convex polygons and connects
+// every pair of polygons whose edges overlap along a segment of positive
+// length. Vertices may be given in either winding order; repeated vertices
+// and vertices in the middle of a straight edge are dropped. Polygons that
+// only touch at a corner are not connected.
+//
+// Params:
+//
+//	polygons: vertex lists of convex polygons with at least 3 vertices; a
+//	polygon's index is its ID
+//
+// Returns:
+//
+//	*NavMesh: new navigation mesh
+//	error: if no polygons are given or a polygon is degenerate or not convex
+func NewNavMesh(polygons [][]Point) (*NavMesh, error) {
+	if len(polygons) == 0 {
+		return nil, errors.New("navigation mesh needs at least one polygon")
+	}
+
+	mesh := &NavMesh{polygons: make([]*Polygon, len(polygons))}
+	for i, vertices := range polygons {
+		poly, err := newPolygon(vertices)
+		if err != nil {
+			return nil, fmt.Errorf("polygon %d: %w", i, err)
+		}
+		mesh.polygons[i] = poly
+	}
+
+	mesh.connect()
+	return mesh, nil
+}
+
+// newPolygon validates a convex polygon, orders its vertices
+// counter-clockwise and drops those that do not form a corner, so that two
+// polygons share at most one edge segment.
+func newPolygon(vertices []Point) (*Polygon, error) {
+	if len(vertices) < 3 {
+		return nil, fmt.Errorf("needs at least 3 vertices, got %d", len(vertices))
+	}
+
+	area := 0.0
+	for i, a := range vertices {
+		b := vertices[(i+1)%len(vertices)]
+		area += a.X*b.Y - b.X*a.Y
+	}
+	if math.Abs(area) <= epsilon {
+		return nil, errors.New("polygon has zero area")
+	}
+
+	ordered := append([]Point(nil), vertices...)
+	if area < 0 {
+		for i, j := 0, len(ordered)-1; i < j; i, j = i+1, j-1 {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		}
+	}
+	poly := &Polygon{Vertices: dropStraightVertices(ordered)}
+	if len(poly.Vertices) < 3 {
+		return nil, errors.New("polygon has zero area")
+	}
+
+	n := len(poly.Vertices)
+	for i := range poly.Vertices {
+		a, b, c := poly.Vertices[i], poly.Vertices[(i+1)%n], poly.Vertices[(i+2)%n]
+		if cross(a, b, c) < -epsilon {
+			return nil, errors.New("polygon is not convex")
+		}
+	}
+	return poly, nil
+}
+
+// dropStraightVertices removes vertices that repeat their predecessor or
+// lie on the line between their neighbors, keeping only true corners.
+func dropStraightVertices(vertices []Point) []Point {
+	straight := func(a, b, c Point) bool {
+		return math.Abs(cross(a, b, c)) <= epsilon*a.Dist(c)
+	}
+
+	corners := make([]Point, 0, len(vertices))
+	for _, v := range vertices {
+		corners = append(corners, v)
+		for n := len(corners); n >= 3 && straight(corners[n-3], corners[n-2], corners[n-1]); n-- {
+			corners = append(corners[:n-2], corners[n-1])
+		}
+	}
+
+	// The loop above never compares across the wrap-around
+	for len(corners) >= 3 {
+		n := len(corners)
+		switch {
+		case straight(corners[n-2], corners[n-1], corners[0]):
+			corners = corners[:n-1]
+		case straight(corners[n-1], corners[0], corners[1]):
+			corners = corners[1:]
+		default:
+			return corners
+		}
+	}
+	return corners
+}
+
+// connect finds the portals between all adjacent polygons. It sorts the
+// bounding boxes along one axis and sweeps, so only polygons whose extents
+// overlap on that axis are compared: O(n log n) plus the number of such
+// pairs. The axis is the one on which the boxes are narrowest relative to
+// the mesh, which keeps meshes of long strips, like those FromGrid builds
+// from corridors, near linear. Polygons crowded into a single band on both
+// axes are still compared pairwise.
+func (m *NavMesh) connect() {
+	boxes := make([][4]float64, len(m.polygons))
+	for i, poly := range m.polygons {
+		boxes[i] = boundingBox(poly.Vertices)
+	}
+	axis := sweepAxis(boxes)
+
+	order := make([]int, len(boxes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return boxes[order[i]][axis] < boxes[order[j]][axis]
+	})
+
+	var pairs [][2]int
+	for k, i := range order {
+		for _, j := range order[k+1:] {
+			if boxes[j][axis] > boxes[i][axis+2]+epsilon {
+				break
+			}
+			if boxesTouch(boxes[i], boxes[j]) {
+				pairs = append(pairs, [2]int{min(i, j), max(i, j)})
+			}
+		}
+	}
+
+	// Link in ID order so portal indices do not depend on the sweep
+	sort.Slice(pairs, func(a, b int) bool {
+		if pairs[a][0] != pairs[b][0] {
+			return pairs[a][0] < pairs[b][0]
+		}
+		return pairs[a][1] < pairs[b][1]
+	})
+	for _, pair := range pairs {
+		m.link(pair[0], pair[1])
+	}
+}
+
+// link adds the portal between polygons i and j if they share an edge
+// segment. Without straight vertices, convex polygons with disjoint
+// interiors share at most one.
+func (m *NavMesh) link(i, j int) {
+	p, q := m.polygons[i], m.polygons[j]
+	for e, a := range p.Vertices {
+		b := p.Vertices[(e+1)%len(p.Vertices)]
+		for f, c := range q.Vertices {
+			d := q.Vertices[(f+1)%len(q.Vertices)]
+			if start, end, ok := sharedSegment(a, b, c, d); ok {
+				m.portals = append(m.portals, portal{a: start, b: end, polys: [2]int{i, j}})
+				p.portals = append(p.portals, len(m.portals)-1)
+				q.portals = append(q.portals, len(m.portals)-1)
+				return
+			}
+		}
+	}
+}
+
+// sweepAxis returns 0 to sweep boxes along x or 1 to sweep along y,
+// whichever axis the boxes cover less of relative to the mesh's extent.
+func sweepAxis(boxes [][4]float64) int {
+	var bounds [4]float64
+	var widths, heights float64
+	for i, box := range boxes {
+		if i == 0 {
+			bounds = box
+		}
+		bounds[0], bounds[1] = math.Min(bounds[0], box[0]), math.Min(bounds[1], box[1])
+		bounds[2], bounds[3] = math.Max(bounds[2], box[2]), math.Max(bounds[3], box[3])
+		widths += box[2] - box[0]
+		heights += box[3] - box[1]
+	}
+	if widths/(bounds[2]-bounds[0]) <= heights/(bounds[3]-bounds[1]) {
+		return 0
+	}
+	return 1
+}
+
+// sharedSegment returns the overlap of edge a -> b with edge c -> d of a
+// neighboring polygon, which runs the opposite way along the same line. The
+// overlap is ordered from a towards b.
+func sharedSegment(a, b, c, d Point) (Point, Point, bool) {
+	length := a.Dist(b)
+	if math.Abs(cross(a, b, c)) > epsilon*length || math.Abs(cross(a, b, d)) > epsilon*length {
+		return Point{}, Point{}, false
+	}
+	dx, dy := b.X-a.X, b.Y-a.Y
+	if dx*(d.X-c.X)+dy*(d.Y-c.Y) >= 0 {
+		return Point{}, Point{}, false
+	}
+
+	// Project c and d onto a -> b as fractions of its length
+	sq := dx*dx + dy*dy
+	tc := ((c.X-a.X)*dx + (c.Y-a.Y)*dy) / sq
+	td := ((d.X-a.X)*dx + (d.Y-a.Y)*dy) / sq
+	t0 := math.Max(0, math.Min(tc, td))
+	t1 := math.Min(1, math.Max(tc, td))
+	if (t1-t0)*length <= epsilon {
+		return Point{}, Point{}, false
+	}
+	return Point{a.X + t0*dx, a.Y + t0*dy}, Point{a.X + t1*dx, a.Y + t1*dy}, true
+}
+
+// boundingBox returns the minimum and maximum coordinates of the vertices
+// as [minX, minY, maxX, maxY].
+func boundingBox(vertices []Point) [4]float64 {
+	box := [4]float64{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
+	for _, v := range vertices {
+		box[0], box[1] = math.Min(box[0], v.X), math.Min(box[1], v.Y)
+		box[2], box[3] = math.Max(box[2], v.X), math.Max(box[3], v.Y)
+	}
+	return box
+}
+
+// boxesTouch reports whether two bounding boxes overlap or touch.
+func boxesTouch(a, b [4]float64) bool {
+	return a[0] <= b[2]+epsilon && b[0] <= a[2]+epsilon &&
+		a[1] <= b[3]+epsilon && b[1] <= a[3]+epsilon
+}
+
+// cross returns twice the signed area of triangle (a, b, c): positive if c
+// lies to the left of the line a -> b, negative if to the right.
+func cross(a, b, c Point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+// Polygon returns the polygon with the given ID.
+//
+// Params:
+//
+//	id: polygon ID
+//
+// Returns:
+//
+//	*Polygon: polygon with the ID
+//	error: if the ID is out of range
+func (m *NavMesh) Polygon(id int) (*Polygon, error) {
+	if id < 0 || id >= len(m.polygons) {
+		return nil, fmt.Errorf("polygon %d does not exist in mesh of %d polygons", id, len(m.polygons))
+	}
+	return m.polygons[id], nil
+}
+
+// PolygonCount returns the number of polygons in the mesh.
+func (m *NavMesh) PolygonCount() int {
+	return len(m.polygons)
+}
+
+// Neighbors returns the IDs of the polygons adjacent to a polygon.
+//
+// Params:
+//
+//	id: polygon ID
+//
+// Returns:
+//
+//	[]int: IDs of adjacent polygons
+func (m *NavMesh) Neighbors(id int) []int {
+	if id < 0 || id >= len(m.polygons) {
+		return nil
+	}
+	neighbors := make([]int, 0, len(m.polygons[id].portals))
+	for _, p := range m.polygons[id].portals {
+		neighbors = append(neighbors, m.portals[p].other(id))
+	}
+	return neighbors
+}
+
+// Locate returns the ID of the polygon containing a point. A point on an
+// edge shared by several polygons belongs to the first of them.
+//
+// Params:
+//
+//	point: point to locate
+//
+// Returns:
+//
+//	int: polygon ID
+//	bool: false if the point lies outside the mesh
+func (m *NavMesh) Locate(point Point) (int, bool) {
+	for i, poly := range m.polygons {
+		if poly.Contains(point) {
+			return i, true
+		}
+	}
+	return -1, false
+}
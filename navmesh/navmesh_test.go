@@ -0,0 +1,186 @@
+package navmesh
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/edgejay/go-pathfinding/algo"
+)
+
+// square returns the vertices of an axis-aligned rectangle
+func square(x0, y0, x1, y1 float64) []Point {
+	return []Point{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}}
+}
+
+func TestNewNavMesh(t *testing.T) {
+	tests := []struct {
+		name     string
+		polygons [][]Point
+	}{
+		{"no polygons", nil},
+		{"too few vertices", [][]Point{{{0, 0}, {1, 0}}}},
+		{"zero area", [][]Point{{{0, 0}, {1, 0}, {2, 0}}}},
+		{"not convex", [][]Point{{{0, 0}, {2, 0}, {2, 2}, {1, 1}, {0, 2}}}},
+	}
+	for _, tt := range tests {
+		if _, err := NewNavMesh(tt.polygons); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+
+	// Clockwise input is reordered counter-clockwise
+	mesh, err := NewNavMesh([][]Point{{{0, 0}, {0, 1}, {1, 1}, {1, 0}}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	poly, _ := mesh.Polygon(0)
+	if poly.Vertices[1] != (Point{1, 1}) {
+		t.Errorf("Expected counter-clockwise vertices, got %v", poly.Vertices)
+	}
+	if c := poly.Centroid(); c != (Point{0.5, 0.5}) {
+		t.Errorf("Expected centroid (0.5, 0.5), got %v", c)
+	}
+	if _, err := mesh.Polygon(1); err == nil {
+		t.Error("Expected error for unknown polygon")
+	}
+}
+
+func TestNavMeshAdjacency(t *testing.T) {
+	mesh, err := NewNavMesh([][]Point{
+		square(0, 0, 2, 1), // 0: spans below 1 and 2
+		square(0, 1, 1, 2), // 1
+		square(1, 1, 2, 2), // 2
+		square(2, 2, 3, 3), // 3: touches 2 at a corner only
+		square(2, 0, 4, 1), // 4: shares the edge x = 2 with 0
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		id   int
+		want []int
+	}{
+		{0, []int{1, 2, 4}},
+		{1, []int{0, 2}},
+		{2, []int{0, 1}},
+		{3, []int{}},
+		{4, []int{0}},
+	}
+	for _, tt := range tests {
+		got := mesh.Neighbors(tt.id)
+		sort.Ints(got)
+		if len(got) != len(tt.want) {
+			t.Errorf("Neighbors(%d) = %v, want %v", tt.id, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("Neighbors(%d) = %v, want %v", tt.id, got, tt.want)
+				break
+			}
+		}
+	}
+
+	// Partial overlaps produce portals of the shared length only
+	for _, p := range mesh.portals {
+		if length := p.a.Dist(p.b); math.Abs(length-1) > 1e-9 {
+			t.Errorf("Portal between %v has length %.3f, expected 1", p.polys, length)
+		}
+	}
+}
+
+// TestNavMeshAdjacency_StraightVertices tests that vertices in the middle
+// of an edge are dropped, so a shared edge split by them is one portal
+func TestNavMeshAdjacency_StraightVertices(t *testing.T) {
+	mesh, err := NewNavMesh([][]Point{
+		{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {0, 1}, {0, 1}},
+		{{0, 1}, {1, 1}, {2, 1}, {2, 2}, {1, 2}, {0, 2}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for id := 0; id < 2; id++ {
+		poly, _ := mesh.Polygon(id)
+		if len(poly.Vertices) != 4 {
+			t.Errorf("Polygon %d: expected 4 corners, got %v", id, poly.Vertices)
+		}
+	}
+	if len(mesh.portals) != 1 {
+		t.Fatalf("Expected 1 portal, got %d", len(mesh.portals))
+	}
+	if length := mesh.portals[0].a.Dist(mesh.portals[0].b); length != 2 {
+		t.Errorf("Expected portal of length 2, got %.3f", length)
+	}
+	if got := mesh.Neighbors(0); len(got) != 1 || got[0] != 1 {
+		t.Errorf("Neighbors(0) = %v, want [1]", got)
+	}
+}
+
+// TestNavMeshAdjacency_Sweep tests that the sweep finds the same portals as
+// comparing every pair of polygons, on meshes of wide and tall strips
+func TestNavMeshAdjacency_Sweep(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	for _, size := range [][2]int{{40, 12}, {12, 40}, {25, 25}} {
+		grid, _ := algo.NewGrid(size[0], size[1], algo.FourWay)
+		for y := 0; y < size[1]; y++ {
+			for x := 0; x < size[0]; x++ {
+				if rng.Float64() < 0.3 {
+					grid.SetObstacle(x, y)
+				}
+			}
+		}
+		mesh, err := FromGrid(grid)
+		if err != nil {
+			t.Fatalf("FromGrid() returned error: %v", err)
+		}
+
+		want := 0
+		for i, p := range mesh.polygons {
+			for _, q := range mesh.polygons[i+1:] {
+				for e, a := range p.Vertices {
+					b := p.Vertices[(e+1)%len(p.Vertices)]
+					for f, c := range q.Vertices {
+						if _, _, ok := sharedSegment(a, b, c, q.Vertices[(f+1)%len(q.Vertices)]); ok {
+							want++
+						}
+					}
+				}
+			}
+		}
+		if len(mesh.portals) != want {
+			t.Errorf("%dx%d grid: expected %d portals, got %d", size[0], size[1], want, len(mesh.portals))
+		}
+	}
+}
+
+func TestNavMeshLocate(t *testing.T) {
+	mesh, _ := NewNavMesh([][]Point{
+		square(0, 0, 1, 1),
+		{{1, 0}, {3, 0}, {1, 2}}, // triangle
+	})
+
+	tests := []struct {
+		point Point
+		id    int
+		found bool
+	}{
+		{Point{0.5, 0.5}, 0, true},
+		{Point{1.5, 0.2}, 1, true},
+		{Point{1, 0.5}, 0, true}, // shared edge belongs to the first polygon
+		{Point{2.5, 1.5}, -1, false},
+		{Point{-1, 0}, -1, false},
+	}
+	for _, tt := range tests {
+		id, found := mesh.Locate(tt.point)
+		if id != tt.id || found != tt.found {
+			t.Errorf("Locate(%v) = (%d, %v), want (%d, %v)", tt.point, id, found, tt.id, tt.found)
+		}
+	}
+	if mesh.PolygonCount() != 2 {
+		t.Errorf("Expected 2 polygons, got %d", mesh.PolygonCount())
+	}
+}
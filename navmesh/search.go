@@ -0,0 +1,213 @@
+package navmesh
+
+import (
+	"fmt"
+
+	"github.com/edgejay/go-pathfinding/algo"
+)
+
+// Result contains a navigation mesh path and search statistics.
+type Result struct {
+	// Path is the taut path of points from start to goal
+	Path []Point
+
+	// Corridor lists the IDs of the polygons the path passes through, from
+	// the start polygon to the goal polygon
+	Corridor []int
+
+	// Cost is the Euclidean length of Path
+	Cost float64
+
+	// NodesExpanded is the number of portals expanded by the search
+	NodesExpanded int
+}
+
+// FindPath finds a path between two points of the mesh.
+//
+// Params:
+//
+//	start: starting point
+//	goal: destination point
+//
+// Returns:
+//
+//	[]Point: taut path from start to goal (including both endpoints)
+//	error: if a point lies outside the mesh or no path exists
+func (m *NavMesh) FindPath(start, goal Point) ([]Point, error) {
+	result, err := m.Search(start, goal)
+	if err != nil {
+		return nil, err
+	}
+	return result.Path, nil
+}
+
+// Search finds a path between two points of the mesh and reports the
+// polygon corridor and statistics.
+//
+// A* runs over the portals, moving between the midpoints of portals on the
+// same polygon and estimating the remaining distance in a straight line.
+// The funnel algorithm then pulls the path taut within the corridor. The
+// path is the shortest one through the chosen corridor; as the corridor is
+// chosen by midpoint distances, a different corridor may occasionally
+// allow a slightly shorter path.
+//
+// Params:
+//
+//	start: starting point
+//	goal: destination point
+//
+// Returns:
+//
+//	*Result: path, corridor and statistics
+//	error: if a point lies outside the mesh or no path exists
+func (m *NavMesh) Search(start, goal Point) (*Result, error) {
+	startPoly, ok := m.Locate(start)
+	if !ok {
+		return nil, fmt.Errorf("start point (%g, %g) is outside the navigation mesh", start.X, start.Y)
+	}
+	goalPoly, ok := m.Locate(goal)
+	if !ok {
+		return nil, fmt.Errorf("goal point (%g, %g) is outside the navigation mesh", goal.X, goal.Y)
+	}
+
+	portals, expanded, ok := m.searchPortals(start, goal, startPoly, goalPoly)
+	if !ok {
+		return nil, fmt.Errorf("no path found from (%g, %g) to (%g, %g)", start.X, start.Y, goal.X, goal.Y)
+	}
+
+	// Consecutive portals share exactly one polygon, the one walked through
+	corridor := []int{startPoly}
+	for i := 1; i < len(portals); i++ {
+		corridor = append(corridor, m.sharedPolygon(portals[i-1], portals[i]))
+	}
+	if len(portals) > 0 {
+		corridor = append(corridor, goalPoly)
+	}
+
+	lefts := make([]Point, len(portals))
+	rights := make([]Point, len(portals))
+	for i, p := range portals {
+		lefts[i], rights[i] = m.portals[p].crossing(corridor[i])
+	}
+	path := Funnel(start, goal, lefts, rights)
+
+	cost := 0.0
+	for i := 1; i < len(path); i++ {
+		cost += path[i-1].Dist(path[i])
+	}
+
+	return &Result{
+		Path:          path,
+		Corridor:      corridor,
+		Cost:          cost,
+		NodesExpanded: expanded,
+	}, nil
+}
+
+// searchPortals runs A* from start to goal over the portals of the mesh,
+// using one algo.Node per portal identified by Node.ID, plus one node each
+// for start and goal.
+//
+// Returns:
+//
+//	[]int: indices of the portals crossed, in order
+//	int: number of expanded nodes
+//	bool: false if the goal polygon cannot be reached
+func (m *NavMesh) searchPortals(start, goal Point, startPoly, goalPoly int) ([]int, int, bool) {
+	if startPoly == goalPoly {
+		return nil, 0, true
+	}
+
+	// Portal i is node i; start and goal follow the portals
+	nodes := make([]*algo.Node, len(m.portals)+2)
+	points := make([]Point, len(nodes))
+	for i := range nodes {
+		nodes[i] = algo.NewNode(0, 0)
+		nodes[i].ID = i
+	}
+	for i := range m.portals {
+		points[i] = m.portals[i].midpoint()
+	}
+	startNode, goalNode := nodes[len(m.portals)], nodes[len(m.portals)+1]
+	points[startNode.ID], points[goalNode.ID] = start, goal
+
+	// successors returns the nodes reachable through the given polygons
+	successors := func(polys []int, from int) []*algo.Node {
+		var next []*algo.Node
+		for _, poly := range polys {
+			if poly == goalPoly {
+				next = append(next, goalNode)
+			}
+			for _, p := range m.polygons[poly].portals {
+				if p != from {
+					next = append(next, nodes[p])
+				}
+			}
+		}
+		return next
+	}
+
+	openSet := algo.NewPriorityQueue()
+	closedSet := make(map[int]bool)
+	startNode.H = start.Dist(goal)
+	startNode.CalculateF()
+	openSet.PushNode(startNode)
+
+	expanded := 0
+	for !openSet.IsEmpty() {
+		current := openSet.PopNode()
+		if current == goalNode {
+			var portals []int
+			for node := current.Parent; node != startNode; node = node.Parent {
+				portals = append(portals, node.ID)
+			}
+			for i, j := 0, len(portals)-1; i < j; i, j = i+1, j-1 {
+				portals[i], portals[j] = portals[j], portals[i]
+			}
+			return portals, expanded, true
+		}
+		closedSet[current.ID] = true
+		expanded++
+
+		var next []*algo.Node
+		if current == startNode {
+			next = successors([]int{startPoly}, -1)
+		} else {
+			next = successors(m.portals[current.ID].polys[:], current.ID)
+		}
+
+		for _, neighbor := range next {
+			if closedSet[neighbor.ID] {
+				continue
+			}
+
+			newG := current.G + points[current.ID].Dist(points[neighbor.ID])
+			inOpen := openSet.ContainsNode(neighbor)
+			if inOpen && newG >= neighbor.G {
+				continue
+			}
+
+			neighbor.G = newG
+			neighbor.H = points[neighbor.ID].Dist(goal)
+			neighbor.CalculateF()
+			neighbor.Parent = current
+			if inOpen {
+				openSet.UpdateNodePriority(neighbor, neighbor.F)
+			} else {
+				openSet.PushNode(neighbor)
+			}
+		}
+	}
+
+	return nil, expanded, false
+}
+
+// sharedPolygon returns the polygon on the boundary of both portals.
+func (m *NavMesh) sharedPolygon(a, b int) int {
+	for _, poly := range m.portals[a].polys {
+		if poly == m.portals[b].polys[0] || poly == m.portals[b].polys[1] {
+			return poly
+		}
+	}
+	return -1
+}
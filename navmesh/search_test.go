@@ -0,0 +1,124 @@
+package navmesh
+
+import (
+	"math"
+	"testing"
+)
+
+// newUMesh builds a U-shaped mesh: two vertical arms joined at the bottom
+//
+//	0 . 2
+//	0 . 2
+//	1 1 1
+func newUMesh(t *testing.T) *NavMesh {
+	t.Helper()
+
+	mesh, err := NewNavMesh([][]Point{
+		square(0, 0, 1, 2), // 0: left arm
+		square(0, 2, 3, 3), // 1: bottom
+		square(2, 0, 3, 2), // 2: right arm
+	})
+	if err != nil {
+		t.Fatalf("NewNavMesh returned error: %v", err)
+	}
+	return mesh
+}
+
+func TestNavMeshSearch(t *testing.T) {
+	mesh := newUMesh(t)
+
+	result, err := mesh.Search(Point{0.5, 0.5}, Point{2.5, 0.5})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+
+	// The path turns around the inner corners (1, 2) and (2, 2)
+	want := []Point{{0.5, 0.5}, {1, 2}, {2, 2}, {2.5, 0.5}}
+	if len(result.Path) != len(want) {
+		t.Fatalf("Path = %v, want %v", result.Path, want)
+	}
+	for i := range want {
+		if result.Path[i] != want[i] {
+			t.Fatalf("Path = %v, want %v", result.Path, want)
+		}
+	}
+
+	wantCost := 2*math.Hypot(0.5, 1.5) + 1
+	if math.Abs(result.Cost-wantCost) > 1e-9 {
+		t.Errorf("Cost = %.4f, want %.4f", result.Cost, wantCost)
+	}
+
+	corridor := []int{0, 1, 2}
+	if len(result.Corridor) != len(corridor) {
+		t.Fatalf("Corridor = %v, want %v", result.Corridor, corridor)
+	}
+	for i := range corridor {
+		if result.Corridor[i] != corridor[i] {
+			t.Fatalf("Corridor = %v, want %v", result.Corridor, corridor)
+		}
+	}
+	if result.NodesExpanded == 0 {
+		t.Error("Expected expanded nodes")
+	}
+
+	// Searching backwards walks the same corners
+	back, err := mesh.FindPath(Point{2.5, 0.5}, Point{0.5, 0.5})
+	if err != nil {
+		t.Fatalf("FindPath returned error: %v", err)
+	}
+	if len(back) != 4 || back[1] != (Point{2, 2}) || back[2] != (Point{1, 2}) {
+		t.Errorf("Reverse path = %v", back)
+	}
+}
+
+func TestNavMeshSearch_SamePolygon(t *testing.T) {
+	mesh := newUMesh(t)
+
+	result, err := mesh.Search(Point{0.2, 0.1}, Point{0.8, 1.9})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(result.Path) != 2 || len(result.Corridor) != 1 || result.Corridor[0] != 0 {
+		t.Errorf("Expected a straight path in polygon 0, got %v through %v", result.Path, result.Corridor)
+	}
+}
+
+// TestNavMeshSearch_Corridor checks that A* picks the shorter of two routes
+// around an obstacle
+func TestNavMeshSearch_Corridor(t *testing.T) {
+	// A ring of four rectangles around the hole [1, 5] x [1, 2]
+	mesh, err := NewNavMesh([][]Point{
+		square(0, 0, 6, 1), // 0: top
+		square(0, 1, 1, 2), // 1: left, short way
+		square(5, 1, 6, 2), // 2: right
+		square(0, 2, 6, 3), // 3: bottom
+	})
+	if err != nil {
+		t.Fatalf("NewNavMesh returned error: %v", err)
+	}
+
+	result, err := mesh.Search(Point{1.5, 0.5}, Point{1.5, 2.5})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(result.Corridor) != 3 || result.Corridor[1] != 1 {
+		t.Errorf("Expected the corridor through polygon 1, got %v", result.Corridor)
+	}
+}
+
+func TestNavMeshSearch_Errors(t *testing.T) {
+	mesh, _ := NewNavMesh([][]Point{
+		square(0, 0, 1, 1),
+		square(2, 0, 3, 1), // disconnected
+	})
+
+	if _, err := mesh.FindPath(Point{-1, 0}, Point{0.5, 0.5}); err == nil {
+		t.Error("Expected error for start outside the mesh")
+	}
+	if _, err := mesh.FindPath(Point{0.5, 0.5}, Point{1.5, 0.5}); err == nil {
+		t.Error("Expected error for goal outside the mesh")
+	}
+	if _, err := mesh.FindPath(Point{0.5, 0.5}, Point{2.5, 0.5}); err == nil {
+		t.Error("Expected error for disconnected polygons")
+	}
+}